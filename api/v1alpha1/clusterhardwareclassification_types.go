@@ -0,0 +1,118 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterHardwareClassificationSpec defines the desired state of
+// ClusterHardwareClassification
+type ClusterHardwareClassificationSpec struct {
+	HardwareClassificationSpec `json:",inline"`
+
+	// +optional
+	// NamespaceSelector selects the namespaces of the hosts considered
+	// by the profile, all of the namespaces are selected if it is not
+	// given
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// NamespaceMatchStatus is the observed state of the profile in one of
+// the selected namespaces
+type NamespaceMatchStatus struct {
+	// Namespace is the name of the namespace
+	Namespace string `json:"namespace"`
+	// ProfileMatchStatus identifies whether the profile matches hosts
+	// in the namespace
+	ProfileMatchStatus ProfileMatchStatus `json:"profileMatchStatus,omitempty"`
+	// MatchedHosts is the count of hosts in the namespace labeled as
+	// matching the profile
+	MatchedHosts int `json:"matchedHosts"`
+	// +optional
+	// Tiers reports the count of hosts in the namespace labeled with
+	// each of the tiers
+	Tiers []TierMatchStatus `json:"tiers,omitempty"`
+	// +optional
+	// ErrorMessage reports why the serial numbers of the profile could
+	// not be read from the ConfigMaps of the namespace
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// ClusterHardwareClassificationStatus defines the observed state of
+// ClusterHardwareClassification
+type ClusterHardwareClassificationStatus struct {
+	// ErrorType indicates the type of failure encountered
+	ErrorType ErrorType `json:"errorType,omitempty"`
+	// ProfileMatchStatus identifies whether the profile matches hosts
+	// in any of the selected namespaces
+	ProfileMatchStatus ProfileMatchStatus `json:"profileMatchStatus,omitempty"`
+	// The last error message reported by the hardwareclassification system
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// +optional
+	// Namespaces reports the status of the profile in each of the
+	// selected namespaces with hosts
+	Namespaces []NamespaceMatchStatus `json:"namespaces,omitempty"`
+	// +optional
+	// MissingRequiredProfiles are the required profiles which do not
+	// exist or are being deleted
+	MissingRequiredProfiles []string `json:"missingRequiredProfiles,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=chwc;chc
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ProfileMatchStatus",type="string",JSONPath=".status.profileMatchStatus",description="Profile Match Status"
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=".status.errorMessage",description="Most recent error"
+
+// ClusterHardwareClassification is the Schema for the
+// clusterhardwareclassifications API, a profile applied to the hosts
+// of every selected namespace
+type ClusterHardwareClassification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterHardwareClassificationSpec   `json:"spec,omitempty"`
+	Status ClusterHardwareClassificationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterHardwareClassificationList contains a list of
+// ClusterHardwareClassification
+type ClusterHardwareClassificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterHardwareClassification `json:"items"`
+}
+
+// Profile returns the profile to classify the hosts of the namespace
+// with
+func (c *ClusterHardwareClassification) Profile(namespace string) *HardwareClassification {
+	return &HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              c.Name,
+			Namespace:         namespace,
+			Labels:            c.Labels,
+			DeletionTimestamp: c.DeletionTimestamp,
+		},
+		Spec: c.Spec.HardwareClassificationSpec,
+	}
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterHardwareClassification{}, &ClusterHardwareClassificationList{})
+}
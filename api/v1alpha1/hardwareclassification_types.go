@@ -16,6 +16,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,6 +26,11 @@ const (
 	// block delete operations until the hosts using the label are
 	// updated.
 	Finalizer string = "hardwareclassification.metal3.io"
+
+	// SerialNumbersLabel is the label of the ConfigMaps holding serial
+	// numbers. The controller only reads and watches ConfigMaps with
+	// this label, whatever its value.
+	SerialNumbersLabel string = "hardwareclassification.metal3.io/serial-numbers"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -36,6 +43,100 @@ type HardwareClassificationSpec struct {
 
 	// HardwareCharacteristics defines expected hardware configurations for Cpu, Disk, Nic and Ram.
 	HardwareCharacteristics HardwareCharacteristics `json:"hardwareCharacteristics,omitempty"`
+
+	// +optional
+	// BaseProfile is the name of a profile whose hardware
+	// characteristics are inherited, a HardwareClassification in the
+	// same namespace or, for a cluster profile, another
+	// ClusterHardwareClassification. The fields set in
+	// HardwareCharacteristics override the inherited ones.
+	BaseProfile string `json:"baseProfile,omitempty"`
+
+	// +optional
+	// RequiredProfiles are the names of other profiles the hosts must
+	// also match, HardwareClassifications in the same namespace or, for
+	// a cluster profile, other ClusterHardwareClassifications
+	RequiredProfiles []string `json:"requiredProfiles,omitempty"`
+
+	// +optional
+	// HostSelector limits the hosts considered by the profile to the
+	// ones with matching labels, all of the hosts in the namespace are
+	// considered if it is not given
+	HostSelector *metav1.LabelSelector `json:"hostSelector,omitempty"`
+
+	// +optional
+	// ExclusiveGroup names a group of profiles of which only the
+	// matching profile with the highest priority labels a host
+	ExclusiveGroup string `json:"exclusiveGroup,omitempty"`
+
+	// +optional
+	// Priority orders the profiles of the exclusive group, the highest
+	// priority wins and ties go to the higher score, then the profile
+	// name
+	Priority int32 `json:"priority,omitempty"`
+
+	// +optional
+	// ClassLabel is the key of a label shared by the profiles of the
+	// exclusive group, set to the name of the profile labeling the host
+	// Ex. ClassLabel: "hardwareclassification.metal3.io/class"
+	ClassLabel string `json:"classLabel,omitempty"`
+
+	// +optional
+	// Scoring gives the hosts matching HardwareCharacteristics a
+	// fitness score from 0 to 100
+	Scoring *Scoring `json:"scoring,omitempty"`
+
+	// +optional
+	// Tiers are ordered from the highest to the lowest. The hosts
+	// matching HardwareCharacteristics are labeled with the name of
+	// the highest tier they meet, and hosts meeting no tier are not
+	// labeled.
+	Tiers []Tier `json:"tiers,omitempty"`
+}
+
+// Tier is a grade of the hosts matching a profile
+type Tier struct {
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// Name of the tier, used as the value of the label of the profile
+	Name string `json:"name"`
+	// +optional
+	// Block is the name of a block of the hardwareCharacteristics the
+	// hosts must meet in addition to the characteristics of the profile
+	// to be in the tier, all of the hosts meet a tier without a block
+	Block string `json:"block,omitempty"`
+}
+
+// Scoring defines the soft constraints of a profile. The score of a
+// host is the weight of the soft constraints it matches as a percentage
+// of the weight of all of them, hosts are scored 100 if there are none.
+type Scoring struct {
+	// +optional
+	// SoftConstraints do not fail the match, each adds its weight to
+	// the score of the hosts it matches
+	SoftConstraints []SoftConstraint `json:"softConstraints,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// Threshold is the lowest score of the hosts labeled as matching
+	// the profile
+	Threshold int32 `json:"threshold,omitempty"`
+}
+
+// SoftConstraint holds characteristics adding to the score of the hosts
+// matching them
+type SoftConstraint struct {
+	// +optional
+	// Block is the name of a block of the hardwareCharacteristics the
+	// hosts must match to get the weight of the constraint
+	Block string `json:"block,omitempty"`
+	// +optional
+	// Name identifies the constraint in the logs
+	Name string `json:"name,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// Weight of the constraint in the score, defaults to 1
+	Weight int32 `json:"weight,omitempty"`
 }
 
 // HardwareCharacteristics details to match with the host
@@ -52,6 +153,93 @@ type HardwareCharacteristics struct {
 	SystemVendor *SystemVendor `json:"systemVendor,omitempty"`
 	// +optional
 	Firmware *Firmware `json:"firmware,omitempty"`
+	// +optional
+	Hostname *Hostname `json:"hostname,omitempty"`
+	// +optional
+	// Blocks names characteristics for allOf, anyOf, not, the soft
+	// constraints and the tiers to refer to, so that each of them is
+	// defined once. A block which is not referred to has no effect.
+	Blocks []NamedCharacteristicsBlock `json:"blocks,omitempty"`
+	// +optional
+	// AllOf lists combinations of blocks which should all match the host
+	AllOf []CharacteristicsExpression `json:"allOf,omitempty"`
+	// +optional
+	// AnyOf lists combinations of blocks of which at least one should
+	// match the host
+	// Ex. AnyOf: [{block: "dell-r640"}, {block: "hpe-dl360"}]
+	AnyOf []CharacteristicsExpression `json:"anyOf,omitempty"`
+	// +optional
+	// Not is a combination of blocks which should not match the host
+	Not *CharacteristicsExpression `json:"not,omitempty"`
+	// +optional
+	// Expression is a CEL expression over the hardware details and
+	// selected metadata and spec fields of the host, it should
+	// evaluate to true
+	// Ex. Expression: "hardware.ramMebibytes / hardware.cpu.count >= 4096"
+	Expression string `json:"expression,omitempty"`
+}
+
+// NamedCharacteristicsBlock is a block of characteristics which the
+// allOf, anyOf and not of HardwareCharacteristics, the soft constraints
+// and the tiers refer to by name
+type NamedCharacteristicsBlock struct {
+	// +kubebuilder:validation:MinLength=1
+	// Name identifies the block
+	Name                 string `json:"name"`
+	CharacteristicsBlock `json:",inline"`
+}
+
+// CharacteristicsExpression combines named blocks in the allOf, anyOf
+// and not of HardwareCharacteristics. The block and at least one of
+// anyOf, if given, should match, e.g. "(A or B) and (C or D)" is
+// written as allOf: [{anyOf: [A, B]}, {anyOf: [C, D]}]. Blocks are
+// referred to by name rather than nested, to keep the size of the CRD
+// schema reasonable.
+type CharacteristicsExpression struct {
+	// +optional
+	// Block is the name of a block which should match the host
+	Block string `json:"block,omitempty"`
+	// +optional
+	// AnyOf lists the names of blocks of which at least one should
+	// match the host
+	AnyOf []string `json:"anyOf,omitempty"`
+}
+
+// CharacteristicsBlock holds the characteristics of a named block, they
+// should all match
+type CharacteristicsBlock struct {
+	// +optional
+	Cpu *Cpu `json:"cpu,omitempty"`
+	// +optional
+	Disk *Disk `json:"disk,omitempty"`
+	// +optional
+	Nic *Nic `json:"nic,omitempty"`
+	// +optional
+	Ram *Ram `json:"ram,omitempty"`
+	// +optional
+	SystemVendor *SystemVendor `json:"systemVendor,omitempty"`
+	// +optional
+	Firmware *Firmware `json:"firmware,omitempty"`
+	// +optional
+	Hostname *Hostname `json:"hostname,omitempty"`
+}
+
+// Hostname contains the host name pattern extracted from the hardware
+// profile
+type Hostname struct {
+	// +optional
+	// Name is compared with the host name reported in the hardware
+	// details of the host
+	// Ex. Name: "r12-stor-*"
+	Name string `json:"name,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Enum=exact;prefix;contains;regex;glob
+	// MatchType is the way Name is compared, defaults to glob
+	MatchType MatchType `json:"matchType,omitempty"`
+	// +optional
+	// ExcludedNames fail the match if the host name matches one of them
+	// Ex. ExcludedNames: [{value: "r12-stor-0[0-3]", matchType: "glob"}]
+	ExcludedNames []StringMatcher `json:"excludedNames,omitempty"`
 }
 
 // SystemVendor contains system vendor details extracted from the hardware profile
@@ -59,7 +247,47 @@ type SystemVendor struct {
 	// +optional
 	Manufacturer string `json:"manufacturer,omitempty"`
 	// +optional
+	// +kubebuilder:validation:Enum=exact;prefix;contains;regex;glob
+	// ManufacturerMatchType is the way Manufacturer is compared,
+	// defaults to exact
+	ManufacturerMatchType MatchType `json:"manufacturerMatchType,omitempty"`
+	// +optional
+	// ExcludedManufacturers fail the match if the manufacturer of the
+	// host matches one of them
+	ExcludedManufacturers []StringMatcher `json:"excludedManufacturers,omitempty"`
+	// +optional
 	ProductName string `json:"productName,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Enum=exact;prefix;contains;regex;glob
+	// ProductNameMatchType is the way ProductName is compared, defaults
+	// to contains
+	// Ex. ProductName: "PowerEdge R6*", ProductNameMatchType: "glob"
+	ProductNameMatchType MatchType `json:"productNameMatchType,omitempty"`
+	// +optional
+	// ExcludedProductNames fail the match if the product name of the
+	// host matches one of them
+	// Ex. ExcludedProductNames: [{value: "PowerEdge R610"}]
+	ExcludedProductNames []StringMatcher `json:"excludedProductNames,omitempty"`
+	// +optional
+	// AllowedSerialNumbers fail the match if the serial number of the
+	// host is not one of them
+	AllowedSerialNumbers *SerialNumberList `json:"allowedSerialNumbers,omitempty"`
+	// +optional
+	// DeniedSerialNumbers fail the match if the serial number of the
+	// host is one of them
+	DeniedSerialNumbers *SerialNumberList `json:"deniedSerialNumbers,omitempty"`
+}
+
+// SerialNumberList lists serial numbers given in the profile, read from
+// a ConfigMap in the namespace of the profile, or both
+type SerialNumberList struct {
+	// +optional
+	Values []string `json:"values,omitempty"`
+	// +optional
+	// ConfigMapKeyRef selects a key of a ConfigMap holding serial
+	// numbers separated by whitespace or commas, the text after a # is
+	// ignored. The ConfigMap needs the SerialNumbersLabel label.
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
 }
 
 // Firmware contains firmware details extracted from the hardware profile
@@ -72,17 +300,61 @@ type BIOS struct {
 	// +optional
 	Vendor string `json:"vendor,omitempty"`
 	// +optional
+	// +kubebuilder:validation:Enum=exact;prefix;contains;regex;glob
+	// VendorMatchType is the way Vendor is compared, defaults to exact
+	VendorMatchType MatchType `json:"vendorMatchType,omitempty"`
+	// +optional
+	// ExcludedVendors fail the match if the bios vendor of the host
+	// matches one of them
+	ExcludedVendors []StringMatcher `json:"excludedVendors,omitempty"`
+	// +optional
+	// MinorVersion is the lowest accepted bios version
 	MinorVersion string `json:"minorVersion,omitempty"`
 	// +optional
+	// MajorVersion is the highest accepted bios version
 	MajorVersion string `json:"majorVersion,omitempty"`
+	// +optional
+	// VersionConstraint is a comma separated list of comparisons the
+	// bios version should satisfy, using the operators >=, <=, >, <, =
+	// and !=. Vendor formats such as "U30 v2.42 (03/15/2021)" are
+	// reduced to their version number before comparing.
+	// Ex. VersionConstraint: ">=2.10.0, <3.0, !=2.12.1"
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Format=date
+	// MinimumReleaseDate is the earliest accepted bios release date
+	// Ex. MinimumReleaseDate: "2021-03-01"
+	MinimumReleaseDate string `json:"minimumReleaseDate,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Format=date
+	// MaximumReleaseDate is the latest accepted bios release date
+	// Ex. MaximumReleaseDate: "2022-12-31"
+	MaximumReleaseDate string `json:"maximumReleaseDate,omitempty"`
 }
 
 // Cpu contains cpu details extracted from the hardware profile
 type Cpu struct {
 	// +optional
-	// +kubebuilder:validation:Enum=x86;x86_64;IAS;AMD64
+	// +kubebuilder:validation:Enum=x86;x86_64;IAS;AMD64;amd64;aarch64;arm64;ppc64le
+	// Architecture is compared with the cpu architecture of the host,
+	// treating equivalent spellings such as AMD64 and x86_64 as the same.
+	// IAS is deprecated and never matches a host.
 	Architecture string `json:"architecture,omitempty"`
 	// +optional
+	// Architectures lists the accepted cpu architectures, the host should
+	// have one of them. It cannot be set together with Architecture.
+	// Ex. Architectures: ["x86_64", "aarch64"]
+	Architectures []CPUArchitecture `json:"architectures,omitempty"`
+	// +optional
+	// Model should match the cpu model name of the host
+	// Ex. Model: {value: "Gold 62[0-9]{2}", matchType: "regex"}
+	Model *StringMatcher `json:"model,omitempty"`
+	// +optional
+	// ExcludedModels fail the match if the cpu model name of the host
+	// matches one of them
+	// Ex. ExcludedModels: [{value: "Gold 6226", matchType: "contains"}]
+	ExcludedModels []StringMatcher `json:"excludedModels,omitempty"`
+	// +optional
 	// +kubebuilder:validation:Minimum=1
 	// MinimumCount of cpu should be greater than 0
 	// Ex. MinimumCount > 0
@@ -106,8 +378,27 @@ type Cpu struct {
 	// Ex. MaximumSpeed: 3200
 	// User wants CPU speed 3.2 (in GHz), then he should specify as 3200 MHz
 	MaximumSpeedMHz int32 `json:"maximumSpeedMHz,omitempty"`
+	// +optional
+	// SpeedTolerance widens MinimumSpeedMHz and MaximumSpeedMHz, as a
+	// percentage of the bounds or in MHz
+	// Ex. SpeedTolerance: "5%" or SpeedTolerance: "100"
+	SpeedTolerance Tolerance `json:"speedTolerance,omitempty"`
+	// +optional
+	// RequiredFlags should all be reported in the cpu flags of the host.
+	// Alternatives are separated by "|", one of them is enough.
+	// Ex. RequiredFlags: ["vmx|svm", "avx512f", "pdpe1gb"]
+	RequiredFlags []string `json:"requiredFlags,omitempty"`
+	// +optional
+	// ForbiddenFlags should not be reported in the cpu flags of the host
+	// Ex. ForbiddenFlags: ["hypervisor"]
+	ForbiddenFlags []string `json:"forbiddenFlags,omitempty"`
 }
 
+// CPUArchitecture is the name of a cpu architecture, IAS is deprecated
+// and never matches a host
+// +kubebuilder:validation:Enum=x86;x86_64;IAS;AMD64;amd64;aarch64;arm64;ppc64le
+type CPUArchitecture string
+
 // Disk contains disk details extracted from the hardware profile
 type Disk struct {
 	// +optional
@@ -117,7 +408,8 @@ type Disk struct {
 	MinimumCount int `json:"minimumCount,omitempty"`
 	// +optional
 	// +kubebuilder:validation:Minimum=1
-	// MinimumIndividualSizeGB should be greater than 0
+	// MinimumIndividualSizeGB should be greater than 0, the size is in
+	// decimal GB (1000^3 bytes)
 	// Ex. MinimumIndividualSizeGB > 0
 	MinimumIndividualSizeGB int64 `json:"minimumIndividualSizeGB,omitempty"`
 	// +optional
@@ -130,8 +422,185 @@ type Disk struct {
 	// Maximum individual size should be greater than 0 and greater than MinimumIndividualSizeGB
 	// Ex. MaximumIndividualSizeGB > 0 && MaximumIndividualSizeGB > MinimumIndividualSizeGB
 	MaximumIndividualSizeGB int64 `json:"maximumIndividualSizeGB,omitempty"`
+	// +optional
+	// MinimumIndividualSize is the minimum size of a disk as a quantity
+	// and takes precedence over MinimumIndividualSizeGB. Binary suffixes
+	// (Ki, Mi, Gi, Ti) are powers of 1024 and decimal suffixes (k, M, G,
+	// T) are powers of 1000.
+	// Ex. MinimumIndividualSize: "1.92T"
+	MinimumIndividualSize *resource.Quantity `json:"minimumIndividualSize,omitempty"`
+	// +optional
+	// MaximumIndividualSize is the maximum size of a disk as a quantity
+	// and takes precedence over MaximumIndividualSizeGB
+	// Ex. MaximumIndividualSize: "500Gi"
+	MaximumIndividualSize *resource.Quantity `json:"maximumIndividualSize,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Enum=HDD;SSD;NVME
+	// Type limits the count and size checks to the disks of that type,
+	// other disks of the host are ignored. Use Groups to check the
+	// disks of several types separately.
+	// Ex. Type: "NVME"
+	Type DiskType `json:"type,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MinimumTotalSizeGB is the minimum sum of the sizes of the disks,
+	// only counting the disks of Type if it is given
+	// Ex. MinimumTotalSizeGB > 0
+	MinimumTotalSizeGB int64 `json:"minimumTotalSizeGB,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MaximumTotalSizeGB is the maximum sum of the sizes of the disks,
+	// only counting the disks of Type if it is given
+	// Ex. MaximumTotalSizeGB > 0 && MaximumTotalSizeGB > MinimumTotalSizeGB
+	MaximumTotalSizeGB int64 `json:"maximumTotalSizeGB,omitempty"`
+	// +optional
+	// MinimumTotalSize is the minimum sum of the sizes of the disks as
+	// a quantity and takes precedence over MinimumTotalSizeGB
+	// Ex. MinimumTotalSize: "48T"
+	MinimumTotalSize *resource.Quantity `json:"minimumTotalSize,omitempty"`
+	// +optional
+	// MaximumTotalSize is the maximum sum of the sizes of the disks as
+	// a quantity and takes precedence over MaximumTotalSizeGB
+	MaximumTotalSize *resource.Quantity `json:"maximumTotalSize,omitempty"`
+	// +optional
+	// Vendor should match the vendor name of every disk, only checking
+	// the disks of Type if it is given
+	// Ex. Vendor: {value: "INTEL", matchType: "exact"}
+	Vendor *StringMatcher `json:"vendor,omitempty"`
+	// +optional
+	// Model should match the model name of every disk, only checking
+	// the disks of Type if it is given
+	// Ex. Model: {value: "^SSDSC2KB", matchType: "regex"}
+	Model *StringMatcher `json:"model,omitempty"`
+	// +optional
+	// DeniedModels fail the match if the model name of any disk of the
+	// host matches one of them
+	DeniedModels []StringMatcher `json:"deniedModels,omitempty"`
+	// +optional
+	// AllowedSerialNumbers fail the match if the serial number of any
+	// of the disks of Type if it is given is not one of them
+	AllowedSerialNumbers *SerialNumberList `json:"allowedSerialNumbers,omitempty"`
+	// +optional
+	// DeniedSerialNumbers fail the match if the serial number of any
+	// disk of the host is one of them
+	DeniedSerialNumbers *SerialNumberList `json:"deniedSerialNumbers,omitempty"`
+	// +optional
+	// Groups select the disks of the host by their details. Unlike the
+	// individual size range above, a disk outside of the filters of a
+	// group does not fail the match, it is just not counted in that
+	// group. Every group must be satisfied.
+	// Ex. 1-2 disks of 200-500GB and at least 6 disks of 4000GB or more
+	Groups []DiskGroup `json:"groups,omitempty"`
+}
+
+// DiskGroup filters the disks of the host and checks how many of them
+// pass the filters
+type DiskGroup struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// Minimum count of disks passing the filters should be greater than 0
+	// Ex. MinimumCount > 0
+	MinimumCount int `json:"minimumCount,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// Maximum count of disks passing the filters should be greater than 0
+	// and greater than MinimumCount
+	// Ex. MaximumCount > 0 && MaximumCount > MinimumCount
+	MaximumCount int `json:"maximumCount,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MinimumIndividualSizeGB is the minimum size of a disk in the group
+	// Ex. MinimumIndividualSizeGB > 0
+	MinimumIndividualSizeGB int64 `json:"minimumIndividualSizeGB,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MaximumIndividualSizeGB is the maximum size of a disk in the group
+	// and should be greater than MinimumIndividualSizeGB
+	MaximumIndividualSizeGB int64 `json:"maximumIndividualSizeGB,omitempty"`
+	// +optional
+	// MinimumIndividualSize is the minimum size of a disk in the group
+	// as a quantity and takes precedence over MinimumIndividualSizeGB
+	MinimumIndividualSize *resource.Quantity `json:"minimumIndividualSize,omitempty"`
+	// +optional
+	// MaximumIndividualSize is the maximum size of a disk in the group
+	// as a quantity and takes precedence over MaximumIndividualSizeGB
+	MaximumIndividualSize *resource.Quantity `json:"maximumIndividualSize,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Enum=HDD;SSD;NVME
+	// Type is the type of a disk in the group
+	Type DiskType `json:"type,omitempty"`
+	// +optional
+	// Vendor should match the vendor name of a disk in the group
+	// Ex. Vendor: {value: "SAMSUNG"}
+	Vendor *StringMatcher `json:"vendor,omitempty"`
+	// +optional
+	// Model should match the model name of a disk in the group
+	// Ex. Model: {value: "MZ7KH", matchType: "prefix"}
+	Model *StringMatcher `json:"model,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MinimumTotalSizeGB is the minimum sum of the sizes of the disks
+	// in the group
+	// Ex. MinimumTotalSizeGB > 0
+	MinimumTotalSizeGB int64 `json:"minimumTotalSizeGB,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MaximumTotalSizeGB is the maximum sum of the sizes of the disks
+	// in the group and should be greater than MinimumTotalSizeGB
+	MaximumTotalSizeGB int64 `json:"maximumTotalSizeGB,omitempty"`
+	// +optional
+	// MinimumTotalSize is the minimum sum of the sizes of the disks in
+	// the group as a quantity and takes precedence over
+	// MinimumTotalSizeGB
+	MinimumTotalSize *resource.Quantity `json:"minimumTotalSize,omitempty"`
+	// +optional
+	// MaximumTotalSize is the maximum sum of the sizes of the disks in
+	// the group as a quantity and takes precedence over
+	// MaximumTotalSizeGB
+	MaximumTotalSize *resource.Quantity `json:"maximumTotalSize,omitempty"`
 }
 
+// MatchType is the way a StringMatcher compares its value
+type MatchType string
+
+const (
+	// MatchTypeExact requires the whole string to be equal to the value
+	MatchTypeExact MatchType = "exact"
+	// MatchTypePrefix requires the string to start with the value
+	MatchTypePrefix MatchType = "prefix"
+	// MatchTypeContains requires the string to contain the value
+	MatchTypeContains MatchType = "contains"
+	// MatchTypeRegex requires the string to match the value as a
+	// regular expression
+	MatchTypeRegex MatchType = "regex"
+	// MatchTypeGlob requires the whole string to match the value as a
+	// shell pattern with *, ? and [] wildcards
+	MatchTypeGlob MatchType = "glob"
+)
+
+// StringMatcher matches a string reported for the host
+type StringMatcher struct {
+	// Value to compare with the string reported for the host
+	Value string `json:"value"`
+	// +optional
+	// +kubebuilder:validation:Enum=exact;prefix;contains;regex;glob
+	// MatchType is the way Value is compared, defaults to exact
+	MatchType MatchType `json:"matchType,omitempty"`
+}
+
+// DiskType is the type of a disk as derived from the details reported
+// for the host
+type DiskType string
+
+const (
+	// DiskTypeHDD is a rotational disk
+	DiskTypeHDD DiskType = "HDD"
+	// DiskTypeSSD is a non-rotational disk which is not an NVMe device
+	DiskTypeSSD DiskType = "SSD"
+	// DiskTypeNVME is an NVMe device, identified by its device name
+	DiskTypeNVME DiskType = "NVME"
+)
+
 // Nic contains nic details extracted from the hardware profile
 type Nic struct {
 	// +optional
@@ -144,13 +613,59 @@ type Nic struct {
 	// Maximum count should be greater than 0 and greater than MinimumCount
 	// Ex. MaximumCount > 0 && MaximumCount > MinimumCount
 	MaximumCount int `json:"maximumCount,omitempty"`
+	// +optional
+	// Selectors filter the NICs of the host by their details. Each
+	// selector has its own count range, applied to the NICs passing
+	// its filters, and every selector must be satisfied.
+	// Ex. at least 2 NICs of 25 Gbps or faster, and at least 1 PXE NIC
+	Selectors []NicSelector `json:"selectors,omitempty"`
+}
+
+// NicSelector filters the NICs of the host and checks how many of them
+// pass the filters
+type NicSelector struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// Minimum count of NICs passing the filters should be greater than 0
+	// Ex. MinimumCount > 0
+	MinimumCount int `json:"minimumCount,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// Maximum count of NICs passing the filters should be greater than 0
+	// and greater than MinimumCount
+	// Ex. MaximumCount > 0 && MaximumCount > MinimumCount
+	MaximumCount int `json:"maximumCount,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MinimumSpeedGbps is the minimum speed of the NIC in Gbps
+	// Ex. MinimumSpeedGbps: 25
+	MinimumSpeedGbps int `json:"minimumSpeedGbps,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MaximumSpeedGbps is the maximum speed of the NIC in Gbps and
+	// should be greater than MinimumSpeedGbps
+	MaximumSpeedGbps int `json:"maximumSpeedGbps,omitempty"`
+	// +optional
+	// PXE, when set, requires the NIC to be (or not to be) PXE bootable
+	PXE *bool `json:"pxe,omitempty"`
+	// +optional
+	// VLANIDs lists the VLANs which should all be available on the NIC
+	VLANIDs []int32 `json:"vlanIds,omitempty"`
+	// +optional
+	// Model should be contained in the model name of the NIC
+	// Ex. Model: "Mellanox"
+	Model string `json:"model,omitempty"`
+	// +optional
+	// Name should be equal to the name of the NIC
+	// Ex. Name: "eno1"
+	Name string `json:"name,omitempty"`
 }
 
 // Ram contains ram details extracted from the hardware profile
 type Ram struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=1
-	// MinimumSizeGB of Ram should be greater than 0
+	// MinimumSizeGB of Ram should be greater than 0, the size is in GiB
 	// Ex. MinimumSizeGB > 0
 	MinimumSizeGB int `json:"minimumSizeGB,omitempty"`
 	// +optional
@@ -158,8 +673,30 @@ type Ram struct {
 	// MaximumSizeGB should be greater than 0 or greater than MinimumSizeGB
 	// Ex. MaximumSizeGB > 0 && MaximumSizeGB > MinimumSizeGB
 	MaximumSizeGB int `json:"maximumSizeGB,omitempty"`
+	// +optional
+	// MinimumSize of Ram as a quantity, takes precedence over
+	// MinimumSizeGB. Binary suffixes (Ki, Mi, Gi, Ti) are powers of 1024
+	// and decimal suffixes (k, M, G, T) are powers of 1000.
+	// Ex. MinimumSize: "192Gi"
+	MinimumSize *resource.Quantity `json:"minimumSize,omitempty"`
+	// +optional
+	// MaximumSize of Ram as a quantity, takes precedence over
+	// MaximumSizeGB
+	// Ex. MaximumSize: "1Ti"
+	MaximumSize *resource.Quantity `json:"maximumSize,omitempty"`
+	// +optional
+	// SizeTolerance widens the minimum and the maximum sizes, as a
+	// percentage of the bounds or as a quantity
+	// Ex. SizeTolerance: "3%" or SizeTolerance: "8Gi"
+	SizeTolerance Tolerance `json:"sizeTolerance,omitempty"`
 }
 
+// Tolerance widens a range, given as a percentage of its bounds such as
+// "5%" or as an absolute amount in the units of the range, which may
+// have a quantity suffix such as "8Gi"
+// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?%|[0-9]+(\.[0-9]+)?([KMGT]i|[kMGT])?)$`
+type Tolerance string
+
 // ProfileMatchStatus represents the state of the HardwareClassification
 type ProfileMatchStatus string
 
@@ -189,7 +726,7 @@ const (
 	// controller is unable to fetch BMH from BMO
 	FetchBMHListFailure ErrorType = "fetch BMH error"
 	// ProfileMisConfigured is an error condition occurring when the
-	// extracted profile is empty.
+	// extracted profile is empty or invalid.
 	ProfileMisConfigured ErrorType = "Empty Profile Error"
 	// Empty is an empty error
 	Empty ErrorType = ""
@@ -215,6 +752,21 @@ type HardwareClassificationStatus struct {
 	ProfileMatchStatus ProfileMatchStatus `json:"profileMatchStatus,omitempty"`
 	// The last error message reported by the hardwareclassification system
 	ErrorMessage string `json:"errorMessage,omitempty"`
+	// +optional
+	// Tiers reports the count of hosts labeled with each of the tiers
+	Tiers []TierMatchStatus `json:"tiers,omitempty"`
+	// +optional
+	// MissingRequiredProfiles are the required profiles which do not
+	// exist or are being deleted
+	MissingRequiredProfiles []string `json:"missingRequiredProfiles,omitempty"`
+}
+
+// TierMatchStatus is the count of hosts labeled with a tier
+type TierMatchStatus struct {
+	// Name of the tier
+	Name string `json:"name"`
+	// MatchedHosts is the count of hosts labeled with the tier
+	MatchedHosts int `json:"matchedHosts"`
 }
 
 // +kubebuilder:object:root=true
@@ -20,12 +20,19 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BIOS) DeepCopyInto(out *BIOS) {
 	*out = *in
+	if in.ExcludedVendors != nil {
+		in, out := &in.ExcludedVendors, &out.ExcludedVendors
+		*out = make([]StringMatcher, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BIOS.
@@ -38,9 +45,211 @@ func (in *BIOS) DeepCopy() *BIOS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CharacteristicsBlock) DeepCopyInto(out *CharacteristicsBlock) {
+	*out = *in
+	if in.Cpu != nil {
+		in, out := &in.Cpu, &out.Cpu
+		*out = new(Cpu)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Disk != nil {
+		in, out := &in.Disk, &out.Disk
+		*out = new(Disk)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nic != nil {
+		in, out := &in.Nic, &out.Nic
+		*out = new(Nic)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ram != nil {
+		in, out := &in.Ram, &out.Ram
+		*out = new(Ram)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SystemVendor != nil {
+		in, out := &in.SystemVendor, &out.SystemVendor
+		*out = new(SystemVendor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Firmware != nil {
+		in, out := &in.Firmware, &out.Firmware
+		*out = new(Firmware)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(Hostname)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CharacteristicsBlock.
+func (in *CharacteristicsBlock) DeepCopy() *CharacteristicsBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(CharacteristicsBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CharacteristicsExpression) DeepCopyInto(out *CharacteristicsExpression) {
+	*out = *in
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CharacteristicsExpression.
+func (in *CharacteristicsExpression) DeepCopy() *CharacteristicsExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(CharacteristicsExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHardwareClassification) DeepCopyInto(out *ClusterHardwareClassification) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHardwareClassification.
+func (in *ClusterHardwareClassification) DeepCopy() *ClusterHardwareClassification {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHardwareClassification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterHardwareClassification) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHardwareClassificationList) DeepCopyInto(out *ClusterHardwareClassificationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterHardwareClassification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHardwareClassificationList.
+func (in *ClusterHardwareClassificationList) DeepCopy() *ClusterHardwareClassificationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHardwareClassificationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterHardwareClassificationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHardwareClassificationSpec) DeepCopyInto(out *ClusterHardwareClassificationSpec) {
+	*out = *in
+	in.HardwareClassificationSpec.DeepCopyInto(&out.HardwareClassificationSpec)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHardwareClassificationSpec.
+func (in *ClusterHardwareClassificationSpec) DeepCopy() *ClusterHardwareClassificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHardwareClassificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHardwareClassificationStatus) DeepCopyInto(out *ClusterHardwareClassificationStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceMatchStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MissingRequiredProfiles != nil {
+		in, out := &in.MissingRequiredProfiles, &out.MissingRequiredProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHardwareClassificationStatus.
+func (in *ClusterHardwareClassificationStatus) DeepCopy() *ClusterHardwareClassificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHardwareClassificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cpu) DeepCopyInto(out *Cpu) {
 	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]CPUArchitecture, len(*in))
+		copy(*out, *in)
+	}
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(StringMatcher)
+		**out = **in
+	}
+	if in.ExcludedModels != nil {
+		in, out := &in.ExcludedModels, &out.ExcludedModels
+		*out = make([]StringMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredFlags != nil {
+		in, out := &in.RequiredFlags, &out.RequiredFlags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForbiddenFlags != nil {
+		in, out := &in.ForbiddenFlags, &out.ForbiddenFlags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cpu.
@@ -56,6 +265,58 @@ func (in *Cpu) DeepCopy() *Cpu {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Disk) DeepCopyInto(out *Disk) {
 	*out = *in
+	if in.MinimumIndividualSize != nil {
+		in, out := &in.MinimumIndividualSize, &out.MinimumIndividualSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaximumIndividualSize != nil {
+		in, out := &in.MaximumIndividualSize, &out.MaximumIndividualSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MinimumTotalSize != nil {
+		in, out := &in.MinimumTotalSize, &out.MinimumTotalSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaximumTotalSize != nil {
+		in, out := &in.MaximumTotalSize, &out.MaximumTotalSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Vendor != nil {
+		in, out := &in.Vendor, &out.Vendor
+		*out = new(StringMatcher)
+		**out = **in
+	}
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(StringMatcher)
+		**out = **in
+	}
+	if in.DeniedModels != nil {
+		in, out := &in.DeniedModels, &out.DeniedModels
+		*out = make([]StringMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedSerialNumbers != nil {
+		in, out := &in.AllowedSerialNumbers, &out.AllowedSerialNumbers
+		*out = new(SerialNumberList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeniedSerialNumbers != nil {
+		in, out := &in.DeniedSerialNumbers, &out.DeniedSerialNumbers
+		*out = new(SerialNumberList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]DiskGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Disk.
@@ -68,10 +329,55 @@ func (in *Disk) DeepCopy() *Disk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskGroup) DeepCopyInto(out *DiskGroup) {
+	*out = *in
+	if in.MinimumIndividualSize != nil {
+		in, out := &in.MinimumIndividualSize, &out.MinimumIndividualSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaximumIndividualSize != nil {
+		in, out := &in.MaximumIndividualSize, &out.MaximumIndividualSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Vendor != nil {
+		in, out := &in.Vendor, &out.Vendor
+		*out = new(StringMatcher)
+		**out = **in
+	}
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(StringMatcher)
+		**out = **in
+	}
+	if in.MinimumTotalSize != nil {
+		in, out := &in.MinimumTotalSize, &out.MinimumTotalSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaximumTotalSize != nil {
+		in, out := &in.MaximumTotalSize, &out.MaximumTotalSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskGroup.
+func (in *DiskGroup) DeepCopy() *DiskGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Firmware) DeepCopyInto(out *Firmware) {
 	*out = *in
-	out.BIOS = in.BIOS
+	in.BIOS.DeepCopyInto(&out.BIOS)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Firmware.
@@ -90,32 +396,63 @@ func (in *HardwareCharacteristics) DeepCopyInto(out *HardwareCharacteristics) {
 	if in.Cpu != nil {
 		in, out := &in.Cpu, &out.Cpu
 		*out = new(Cpu)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Disk != nil {
 		in, out := &in.Disk, &out.Disk
 		*out = new(Disk)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Nic != nil {
 		in, out := &in.Nic, &out.Nic
 		*out = new(Nic)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Ram != nil {
 		in, out := &in.Ram, &out.Ram
 		*out = new(Ram)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.SystemVendor != nil {
 		in, out := &in.SystemVendor, &out.SystemVendor
 		*out = new(SystemVendor)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Firmware != nil {
 		in, out := &in.Firmware, &out.Firmware
 		*out = new(Firmware)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(Hostname)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Blocks != nil {
+		in, out := &in.Blocks, &out.Blocks
+		*out = make([]NamedCharacteristicsBlock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllOf != nil {
+		in, out := &in.AllOf, &out.AllOf
+		*out = make([]CharacteristicsExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]CharacteristicsExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Not != nil {
+		in, out := &in.Not, &out.Not
+		*out = new(CharacteristicsExpression)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -135,7 +472,7 @@ func (in *HardwareClassification) DeepCopyInto(out *HardwareClassification) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareClassification.
@@ -192,6 +529,26 @@ func (in *HardwareClassificationList) DeepCopyObject() runtime.Object {
 func (in *HardwareClassificationSpec) DeepCopyInto(out *HardwareClassificationSpec) {
 	*out = *in
 	in.HardwareCharacteristics.DeepCopyInto(&out.HardwareCharacteristics)
+	if in.RequiredProfiles != nil {
+		in, out := &in.RequiredProfiles, &out.RequiredProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostSelector != nil {
+		in, out := &in.HostSelector, &out.HostSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scoring != nil {
+		in, out := &in.Scoring, &out.Scoring
+		*out = new(Scoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]Tier, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareClassificationSpec.
@@ -207,6 +564,16 @@ func (in *HardwareClassificationSpec) DeepCopy() *HardwareClassificationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareClassificationStatus) DeepCopyInto(out *HardwareClassificationStatus) {
 	*out = *in
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]TierMatchStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingRequiredProfiles != nil {
+		in, out := &in.MissingRequiredProfiles, &out.MissingRequiredProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareClassificationStatus.
@@ -219,9 +586,72 @@ func (in *HardwareClassificationStatus) DeepCopy() *HardwareClassificationStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hostname) DeepCopyInto(out *Hostname) {
+	*out = *in
+	if in.ExcludedNames != nil {
+		in, out := &in.ExcludedNames, &out.ExcludedNames
+		*out = make([]StringMatcher, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hostname.
+func (in *Hostname) DeepCopy() *Hostname {
+	if in == nil {
+		return nil
+	}
+	out := new(Hostname)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedCharacteristicsBlock) DeepCopyInto(out *NamedCharacteristicsBlock) {
+	*out = *in
+	in.CharacteristicsBlock.DeepCopyInto(&out.CharacteristicsBlock)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedCharacteristicsBlock.
+func (in *NamedCharacteristicsBlock) DeepCopy() *NamedCharacteristicsBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedCharacteristicsBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceMatchStatus) DeepCopyInto(out *NamespaceMatchStatus) {
+	*out = *in
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]TierMatchStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceMatchStatus.
+func (in *NamespaceMatchStatus) DeepCopy() *NamespaceMatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceMatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nic) DeepCopyInto(out *Nic) {
 	*out = *in
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]NicSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nic.
@@ -234,9 +664,44 @@ func (in *Nic) DeepCopy() *Nic {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NicSelector) DeepCopyInto(out *NicSelector) {
+	*out = *in
+	if in.PXE != nil {
+		in, out := &in.PXE, &out.PXE
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VLANIDs != nil {
+		in, out := &in.VLANIDs, &out.VLANIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NicSelector.
+func (in *NicSelector) DeepCopy() *NicSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NicSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ram) DeepCopyInto(out *Ram) {
 	*out = *in
+	if in.MinimumSize != nil {
+		in, out := &in.MinimumSize, &out.MinimumSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaximumSize != nil {
+		in, out := &in.MaximumSize, &out.MaximumSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ram.
@@ -249,9 +714,104 @@ func (in *Ram) DeepCopy() *Ram {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scoring) DeepCopyInto(out *Scoring) {
+	*out = *in
+	if in.SoftConstraints != nil {
+		in, out := &in.SoftConstraints, &out.SoftConstraints
+		*out = make([]SoftConstraint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scoring.
+func (in *Scoring) DeepCopy() *Scoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Scoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialNumberList) DeepCopyInto(out *SerialNumberList) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialNumberList.
+func (in *SerialNumberList) DeepCopy() *SerialNumberList {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialNumberList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoftConstraint) DeepCopyInto(out *SoftConstraint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoftConstraint.
+func (in *SoftConstraint) DeepCopy() *SoftConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(SoftConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StringMatcher) DeepCopyInto(out *StringMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StringMatcher.
+func (in *StringMatcher) DeepCopy() *StringMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(StringMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemVendor) DeepCopyInto(out *SystemVendor) {
 	*out = *in
+	if in.ExcludedManufacturers != nil {
+		in, out := &in.ExcludedManufacturers, &out.ExcludedManufacturers
+		*out = make([]StringMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedProductNames != nil {
+		in, out := &in.ExcludedProductNames, &out.ExcludedProductNames
+		*out = make([]StringMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedSerialNumbers != nil {
+		in, out := &in.AllowedSerialNumbers, &out.AllowedSerialNumbers
+		*out = new(SerialNumberList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeniedSerialNumbers != nil {
+		in, out := &in.DeniedSerialNumbers, &out.DeniedSerialNumbers
+		*out = new(SerialNumberList)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemVendor.
@@ -263,3 +823,33 @@ func (in *SystemVendor) DeepCopy() *SystemVendor {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tier) DeepCopyInto(out *Tier) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tier.
+func (in *Tier) DeepCopy() *Tier {
+	if in == nil {
+		return nil
+	}
+	out := new(Tier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TierMatchStatus) DeepCopyInto(out *TierMatchStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TierMatchStatus.
+func (in *TierMatchStatus) DeepCopy() *TierMatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TierMatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
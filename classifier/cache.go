@@ -0,0 +1,34 @@
+package classifier
+
+import (
+	"sync"
+)
+
+// cache keeps compiled patterns by their source. It is emptied when it
+// is full, so that the patterns of edited or deleted profiles are not
+// kept forever.
+type cache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]interface{}
+}
+
+func newCache(size int) *cache {
+	return &cache{size: size, entries: map[string]interface{}{}}
+}
+
+func (c *cache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.entries[key]
+	return value, ok
+}
+
+func (c *cache) add(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.size {
+		c.entries = map[string]interface{}{}
+	}
+	c.entries[key] = value
+}
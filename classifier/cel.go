@@ -0,0 +1,312 @@
+package classifier
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/pkg/errors"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+var (
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+
+	// celPrograms caches the compiled expressions by their source
+	celPrograms = newCache(1024)
+)
+
+// celObjectTypes declares the fields of the objects given to
+// expressions, so misspelled fields are reported when the expression is
+// compiled rather than failing for every host. The fields match the
+// maps built by hardwareVariable and hostVariable.
+var celObjectTypes = map[string]map[string]*exprpb.Type{
+	"hardwareclassification.Hardware": {
+		"systemVendor": decls.NewObjectType("hardwareclassification.SystemVendor"),
+		"firmware":     decls.NewObjectType("hardwareclassification.Firmware"),
+		"ramMebibytes": decls.Int,
+		"nics":         decls.NewListType(decls.NewObjectType("hardwareclassification.NIC")),
+		"storage":      decls.NewListType(decls.NewObjectType("hardwareclassification.Storage")),
+		"cpu":          decls.NewObjectType("hardwareclassification.CPU"),
+		"hostname":     decls.String,
+	},
+	"hardwareclassification.SystemVendor": {
+		"manufacturer": decls.String,
+		"productName":  decls.String,
+		"serialNumber": decls.String,
+	},
+	"hardwareclassification.Firmware": {
+		"bios": decls.NewObjectType("hardwareclassification.BIOS"),
+	},
+	"hardwareclassification.BIOS": {
+		"date":    decls.String,
+		"vendor":  decls.String,
+		"version": decls.String,
+	},
+	"hardwareclassification.NIC": {
+		"name":      decls.String,
+		"model":     decls.String,
+		"mac":       decls.String,
+		"ip":        decls.String,
+		"speedGbps": decls.Int,
+		"vlans":     decls.NewListType(decls.NewObjectType("hardwareclassification.VLAN")),
+		"vlanId":    decls.Int,
+		"pxe":       decls.Bool,
+	},
+	"hardwareclassification.VLAN": {
+		"id":   decls.Int,
+		"name": decls.String,
+	},
+	"hardwareclassification.Storage": {
+		"name":         decls.String,
+		"rotational":   decls.Bool,
+		"type":         decls.String,
+		"sizeBytes":    decls.Int,
+		"vendor":       decls.String,
+		"model":        decls.String,
+		"serialNumber": decls.String,
+		"wwn":          decls.String,
+		"hctl":         decls.String,
+	},
+	"hardwareclassification.CPU": {
+		"arch":           decls.String,
+		"model":          decls.String,
+		"clockMegahertz": decls.Double,
+		"flags":          decls.NewListType(decls.String),
+		"count":          decls.Int,
+	},
+	"hardwareclassification.Host": {
+		"name":                  decls.String,
+		"namespace":             decls.String,
+		"labels":                decls.NewMapType(decls.String, decls.String),
+		"annotations":           decls.NewMapType(decls.String, decls.String),
+		"online":                decls.Bool,
+		"bootMode":              decls.String,
+		"hardwareProfile":       decls.String,
+		"externallyProvisioned": decls.Bool,
+		"consumed":              decls.Bool,
+	},
+}
+
+// celTypeProvider adds the object types of the variables to the
+// default provider. The values of the variables are maps, so the fields
+// are only declared for type-checking and read from the maps when the
+// expression is evaluated.
+type celTypeProvider struct {
+	ref.TypeProvider
+}
+
+func (p celTypeProvider) FindType(typeName string) (*exprpb.Type, bool) {
+	if _, ok := celObjectTypes[typeName]; ok {
+		return decls.NewTypeType(decls.NewObjectType(typeName)), true
+	}
+	return p.TypeProvider.FindType(typeName)
+}
+
+func (p celTypeProvider) FindFieldType(messageType string, fieldName string) (*ref.FieldType, bool) {
+	fields, ok := celObjectTypes[messageType]
+	if !ok {
+		return p.TypeProvider.FindFieldType(messageType, fieldName)
+	}
+	fieldType, ok := fields[fieldName]
+	if !ok {
+		return nil, false
+	}
+	return &ref.FieldType{Type: fieldType}, true
+}
+
+// getCELEnv returns the environment declaring the variables available
+// to expressions
+func getCELEnv() (*cel.Env, error) {
+	celEnvOnce.Do(func() {
+		celEnv, celEnvErr = cel.NewEnv(
+			cel.CustomTypeProvider(celTypeProvider{TypeProvider: types.NewRegistry()}),
+			cel.Declarations(
+				decls.NewVar("hardware", decls.NewObjectType("hardwareclassification.Hardware")),
+				decls.NewVar("host", decls.NewObjectType("hardwareclassification.Host")),
+			),
+		)
+	})
+	return celEnv, celEnvErr
+}
+
+// compileCELExpression parses and type-checks the expression, which
+// should evaluate to a bool
+func compileCELExpression(expression string) (cel.Program, error) {
+	if program, ok := celPrograms.get(expression); ok {
+		return program.(cel.Program), nil
+	}
+
+	env, err := getCELEnv()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the CEL environment")
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if !isBoolOrDyn(ast.ResultType()) {
+		return nil, fmt.Errorf("expression should evaluate to a bool, not %v", ast.ResultType())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	celPrograms.add(expression, program)
+	return program, nil
+}
+
+func isBoolOrDyn(t *exprpb.Type) bool {
+	return t.GetPrimitive() == exprpb.Type_BOOL || t.GetDyn() != nil
+}
+
+// checkCELExpression evaluates the expression of the profile for the
+// host, an expression failing to evaluate does not match
+func checkCELExpression(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) bool {
+	expression := profile.Spec.HardwareCharacteristics.Expression
+	if expression == "" {
+		return true
+	}
+
+	ok, err := evalCELExpression(expression, host)
+	if err != nil {
+		log.Info("Expression",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"expression", expression,
+			"error", err.Error(),
+			"ok", false,
+		)
+		return false
+	}
+	log.Info("Expression",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"expression", expression,
+		"ok", ok,
+	)
+	return ok
+}
+
+func evalCELExpression(expression string, host *bmh.BareMetalHost) (bool, error) {
+	program, err := compileCELExpression(expression)
+	if err != nil {
+		return false, err
+	}
+	result, _, err := program.Eval(map[string]interface{}{
+		"hardware": hardwareVariable(host.Status.HardwareDetails),
+		"host":     hostVariable(host),
+	})
+	if err != nil {
+		return false, err
+	}
+	if result.Type() != types.BoolType {
+		return false, fmt.Errorf("expression evaluated to %v, not a bool", result.Type())
+	}
+	return result.Value().(bool), nil
+}
+
+// hardwareVariable returns the hardware details in the form given to
+// expressions, with the field names of the BareMetalHost status
+func hardwareVariable(details *bmh.HardwareDetails) map[string]interface{} {
+	nics := []interface{}{}
+	for _, nic := range details.NIC {
+		vlans := []interface{}{}
+		for _, vlan := range nic.VLANs {
+			vlans = append(vlans, map[string]interface{}{
+				"id":   int64(vlan.ID),
+				"name": vlan.Name,
+			})
+		}
+		nics = append(nics, map[string]interface{}{
+			"name":      nic.Name,
+			"model":     nic.Model,
+			"mac":       nic.MAC,
+			"ip":        nic.IP,
+			"speedGbps": int64(nic.SpeedGbps),
+			"vlans":     vlans,
+			"vlanId":    int64(nic.VLANID),
+			"pxe":       nic.PXE,
+		})
+	}
+
+	storage := []interface{}{}
+	for _, disk := range details.Storage {
+		storage = append(storage, map[string]interface{}{
+			"name":         disk.Name,
+			"rotational":   disk.Rotational,
+			"type":         string(getDiskType(&disk)),
+			"sizeBytes":    int64(disk.SizeBytes),
+			"vendor":       disk.Vendor,
+			"model":        disk.Model,
+			"serialNumber": disk.SerialNumber,
+			"wwn":          disk.WWN,
+			"hctl":         disk.HCTL,
+		})
+	}
+
+	flags := []interface{}{}
+	for _, flag := range details.CPU.Flags {
+		flags = append(flags, flag)
+	}
+
+	return map[string]interface{}{
+		"systemVendor": map[string]interface{}{
+			"manufacturer": details.SystemVendor.Manufacturer,
+			"productName":  details.SystemVendor.ProductName,
+			"serialNumber": details.SystemVendor.SerialNumber,
+		},
+		"firmware": map[string]interface{}{
+			"bios": map[string]interface{}{
+				"date":    details.Firmware.BIOS.Date,
+				"vendor":  details.Firmware.BIOS.Vendor,
+				"version": details.Firmware.BIOS.Version,
+			},
+		},
+		"ramMebibytes": int64(details.RAMMebibytes),
+		"nics":         nics,
+		"storage":      storage,
+		"cpu": map[string]interface{}{
+			"arch":           details.CPU.Arch,
+			"model":          details.CPU.Model,
+			"clockMegahertz": float64(details.CPU.ClockMegahertz),
+			"flags":          flags,
+			"count":          int64(details.CPU.Count),
+		},
+		"hostname": details.Hostname,
+	}
+}
+
+// hostVariable returns the metadata and spec fields of the host given
+// to expressions
+func hostVariable(host *bmh.BareMetalHost) map[string]interface{} {
+	labels := map[string]interface{}{}
+	for key, value := range host.Labels {
+		labels[key] = value
+	}
+	annotations := map[string]interface{}{}
+	for key, value := range host.Annotations {
+		annotations[key] = value
+	}
+	return map[string]interface{}{
+		"name":                  host.Name,
+		"namespace":             host.Namespace,
+		"labels":                labels,
+		"annotations":           annotations,
+		"online":                host.Spec.Online,
+		"bootMode":              string(host.Spec.BootMode),
+		"hardwareProfile":       host.Spec.HardwareProfile,
+		"externallyProvisioned": host.Spec.ExternallyProvisioned,
+		"consumed":              host.Spec.ConsumerRef != nil,
+	}
+}
@@ -0,0 +1,185 @@
+package classifier
+
+import (
+	"testing"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestCompileCELExpression(t *testing.T) {
+	testCases := []struct {
+		Scenario   string
+		Expression string
+		Valid      bool
+	}{
+		{
+			Scenario:   "valid",
+			Expression: "hardware.ramMebibytes / hardware.cpu.count >= 4096",
+			Valid:      true,
+		},
+		{
+			Scenario:   "macro",
+			Expression: "hardware.nics.exists(n, n.speedGbps >= 25 && n.pxe)",
+			Valid:      true,
+		},
+		{
+			Scenario:   "syntax-error",
+			Expression: "hardware.ramMebibytes >=",
+			Valid:      false,
+		},
+		{
+			Scenario:   "undeclared-variable",
+			Expression: "ram >= 4096",
+			Valid:      false,
+		},
+		{
+			Scenario:   "not-bool",
+			Expression: "1 + 2",
+			Valid:      false,
+		},
+		{
+			Scenario:   "misspelled-field",
+			Expression: "hardware.ramMebibyts >= 4096",
+			Valid:      false,
+		},
+		{
+			Scenario:   "misspelled-nested-field",
+			Expression: "hardware.nics.exists(n, n.speedGbs >= 25)",
+			Valid:      false,
+		},
+		{
+			Scenario:   "mismatched-type",
+			Expression: "hardware.cpu.count == '40'",
+			Valid:      false,
+		},
+		{
+			Scenario:   "presence-test",
+			Expression: "has(host.labels.rack) && host.hardwareProfile == ''",
+			Valid:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			_, err := compileCELExpression(tc.Expression)
+			if tc.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckCELExpression(t *testing.T) {
+	host := bmh.BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "r12-stor-07",
+			Namespace: "metal3",
+			Labels: map[string]string{
+				"rack": "r12",
+			},
+		},
+		Spec: bmh.BareMetalHostSpec{
+			Online: true,
+		},
+		Status: bmh.BareMetalHostStatus{
+			HardwareDetails: &bmh.HardwareDetails{
+				RAMMebibytes: 192 * 1024,
+				CPU: bmh.CPU{
+					Count:          40,
+					ClockMegahertz: 2400,
+					Flags:          []string{"avx512f", "vmx"},
+				},
+				NIC: []bmh.NIC{
+					{Name: "eno1", SpeedGbps: 1, PXE: true},
+					{Name: "ens1f0", SpeedGbps: 25, PXE: false},
+				},
+				Storage: []bmh.Storage{
+					{Name: "/dev/nvme0n1", SizeBytes: 1920 * bmh.GigaByte},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Scenario   string
+		Expression string
+		Expected   bool
+	}{
+		{
+			Scenario: "empty",
+			Expected: true,
+		},
+		{
+			Scenario:   "ram-per-cpu-matched",
+			Expression: "hardware.ramMebibytes / hardware.cpu.count >= 4096",
+			Expected:   true,
+		},
+		{
+			Scenario:   "ram-per-cpu-unmatched",
+			Expression: "hardware.ramMebibytes / hardware.cpu.count >= 8192",
+			Expected:   false,
+		},
+		{
+			Scenario:   "pxe-nic-unmatched",
+			Expression: "hardware.nics.exists(n, n.speedGbps >= 25 && n.pxe)",
+			Expected:   false,
+		},
+		{
+			Scenario:   "nic-matched",
+			Expression: "hardware.nics.exists(n, n.speedGbps >= 25)",
+			Expected:   true,
+		},
+		{
+			Scenario:   "flags-and-clock",
+			Expression: "'avx512f' in hardware.cpu.flags && hardware.cpu.clockMegahertz >= 2000.0",
+			Expected:   true,
+		},
+		{
+			Scenario:   "disk-type",
+			Expression: "hardware.storage.all(d, d.type == 'NVME')",
+			Expected:   true,
+		},
+		{
+			Scenario:   "host-fields",
+			Expression: "host.online && host.labels['rack'] == 'r12' && host.name.startsWith('r12-')",
+			Expected:   true,
+		},
+		{
+			Scenario:   "presence-test",
+			Expression: "has(host.labels.rack) && !has(host.labels.role)",
+			Expected:   true,
+		},
+		{
+			Scenario:   "nested-fields",
+			Expression: "hardware.firmware.bios.vendor == '' && hardware.nics.all(n, n.vlans.size() == 0)",
+			Expected:   true,
+		},
+		{
+			Scenario:   "evaluation-error",
+			Expression: "host.labels['role'] == 'storage'",
+			Expected:   false,
+		},
+		{
+			Scenario:   "invalid",
+			Expression: "hardware.ramMebibytes >=",
+			Expected:   false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Expression: tc.Expression,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
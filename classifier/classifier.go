@@ -2,6 +2,7 @@ package classifier
 
 import (
 	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
@@ -9,26 +10,18 @@ import (
 
 var log = ctrl.Log.WithName("classifier")
 
+// ProfileMatchesHost checks whether the host matches the profile. The
+// profile is expected to have passed ValidateProfile, which callers run
+// once per profile rather than for every host.
 func ProfileMatchesHost(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) bool {
-	if !checkSystemVendor(profile, host) {
+	if !checkHostSelector(profile, host) {
 		return false
 	}
-	if !checkFirmware(profile, host) {
+	expr := characteristicsExpression(&profile.Spec.HardwareCharacteristics)
+	if !checkExpression(profile, host, &expr, field.NewPath("spec", "hardwareCharacteristics")) {
 		return false
 	}
-	if !checkCPU(profile, host) {
-		return false
-	}
-	if !checkRAM(profile, host) {
-		return false
-	}
-	if !checkNICs(profile, host) {
-		return false
-	}
-	if !checkDisks(profile, host) {
-		return false
-	}
-	return true
+	return checkCELExpression(profile, host)
 }
 
 func checkRangeInt(min, max, count int) bool {
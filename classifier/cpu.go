@@ -1,9 +1,12 @@
 package classifier
 
 import (
+	"strings"
+
 	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+	"github.com/metal3-io/hardware-classification-controller/utils"
 )
 
 // checkCPU it filters the bmh host as per the hardware details provided by user
@@ -30,10 +33,13 @@ func checkCPU(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) boo
 		return false
 	}
 
-	ok = checkRangeClockSpeed(
-		bmh.ClockSpeed(cpuDetails.MinimumSpeedMHz),
-		bmh.ClockSpeed(cpuDetails.MaximumSpeedMHz),
-		host.Status.HardwareDetails.CPU.ClockMegahertz)
+	// Turbo states make the reported clock speed jitter, so the range
+	// can be widened by a tolerance.
+	ok, tolerated := checkRangeTolerance(
+		float64(cpuDetails.MinimumSpeedMHz),
+		float64(cpuDetails.MaximumSpeedMHz),
+		float64(host.Status.HardwareDetails.CPU.ClockMegahertz),
+		cpuDetails.SpeedTolerance)
 	log.Info("CPU",
 		"host", host.Name,
 		"profile", profile.Name,
@@ -41,20 +47,29 @@ func checkCPU(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) boo
 		"minSpeed", cpuDetails.MinimumSpeedMHz,
 		"maxSpeed", cpuDetails.MaximumSpeedMHz,
 		"actualSpeed", host.Status.HardwareDetails.CPU.ClockMegahertz,
+		"tolerance", cpuDetails.SpeedTolerance,
+		"matchedByTolerance", tolerated,
 		"ok", ok,
 	)
 	if !ok {
 		return false
 	}
 
+	expectedArchs := []string{}
+	if cpuDetails.Architecture != "" {
+		expectedArchs = append(expectedArchs, cpuDetails.Architecture)
+	}
+	for _, arch := range cpuDetails.Architectures {
+		expectedArchs = append(expectedArchs, string(arch))
+	}
 	ok = checkCPUArch(
-		cpuDetails.Architecture,
+		expectedArchs,
 		host.Status.HardwareDetails.CPU.Arch)
 	log.Info("CPU",
 		"host", host.Name,
 		"profile", profile.Name,
 		"namespace", host.Namespace,
-		"architecture", cpuDetails.Architecture,
+		"architecture", expectedArchs,
 		"actualArchitecture", host.Status.HardwareDetails.CPU.Arch,
 		"ok", ok,
 	)
@@ -62,26 +77,98 @@ func checkCPU(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) boo
 		return false
 	}
 
+	ok = checkStringMatcher(cpuDetails.Model, host.Status.HardwareDetails.CPU.Model) &&
+		!checkAnyStringMatcher(cpuDetails.ExcludedModels, host.Status.HardwareDetails.CPU.Model)
+	log.Info("CPU",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"model", cpuDetails.Model,
+		"excludedModels", cpuDetails.ExcludedModels,
+		"actualModel", host.Status.HardwareDetails.CPU.Model,
+		"ok", ok,
+	)
+	if !ok {
+		return false
+	}
+
+	missingFlags, forbiddenFlags := checkCPUFlags(
+		cpuDetails.RequiredFlags,
+		cpuDetails.ForbiddenFlags,
+		host.Status.HardwareDetails.CPU.Flags)
+	ok = len(missingFlags) == 0 && len(forbiddenFlags) == 0
+	log.Info("CPU",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"requiredFlags", cpuDetails.RequiredFlags,
+		"forbiddenFlags", cpuDetails.ForbiddenFlags,
+		"missingFlags", missingFlags,
+		"foundForbiddenFlags", forbiddenFlags,
+		"ok", ok,
+	)
+	if !ok {
+		return false
+	}
+
 	return true
 }
 
-// checkCPUArch checks the cpu arch type
-func checkCPUArch(expectedArch, hostSpecificArch string) bool {
-	if expectedArch != "" {
-		if expectedArch != hostSpecificArch {
-			return false
+// checkCPUFlags returns the required flags missing from the host flags
+// and the forbidden flags found in them
+func checkCPUFlags(required, forbidden, hostFlags []string) (missing, found []string) {
+	for _, flag := range required {
+		alternatives := strings.Split(flag, "|")
+		ok := false
+		for _, alternative := range alternatives {
+			if utils.StringInList(hostFlags, strings.TrimSpace(alternative)) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			missing = append(missing, flag)
 		}
 	}
-	return true
+	for _, flag := range forbidden {
+		if utils.StringInList(hostFlags, flag) {
+			found = append(found, flag)
+		}
+	}
+	return
 }
 
-//  checkRangeClockSpeed checks the cpu clockspeed range
-func checkRangeClockSpeed(min, max, count bmh.ClockSpeed) bool {
-	if min > 0 && count < min {
-		return false
+// archAliases maps the spellings of the cpu architectures to the names
+// reported by the kernel
+var archAliases = map[string]string{
+	"amd64":   "x86_64",
+	"x64":     "x86_64",
+	"x86-64":  "x86_64",
+	"i386":    "x86",
+	"i686":    "x86",
+	"arm64":   "aarch64",
+	"ppc64el": "ppc64le",
+}
+
+// canonicalArch returns the name reported by the kernel for the cpu
+// architecture
+func canonicalArch(arch string) string {
+	arch = strings.ToLower(strings.TrimSpace(arch))
+	if canonical, ok := archAliases[arch]; ok {
+		return canonical
 	}
-	if max > 0 && count > max {
-		return false
+	return arch
+}
+
+// checkCPUArch checks the cpu arch type is one of the expected ones
+func checkCPUArch(expectedArchs []string, hostSpecificArch string) bool {
+	if len(expectedArchs) == 0 {
+		return true
 	}
-	return true
+	for _, arch := range expectedArchs {
+		if canonicalArch(arch) == canonicalArch(hostSpecificArch) {
+			return true
+		}
+	}
+	return false
 }
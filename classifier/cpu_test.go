@@ -9,14 +9,6 @@ import (
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
 )
 
-func TestCheckRangeClockSpeed(t *testing.T) {
-	assert.True(t, checkRangeClockSpeed(bmh.ClockSpeed(0), bmh.ClockSpeed(0), bmh.ClockSpeed(99)))
-	assert.True(t, checkRangeClockSpeed(bmh.ClockSpeed(0), bmh.ClockSpeed(100), bmh.ClockSpeed(99)))
-	assert.True(t, checkRangeClockSpeed(bmh.ClockSpeed(1), bmh.ClockSpeed(0), bmh.ClockSpeed(99)))
-	assert.False(t, checkRangeClockSpeed(bmh.ClockSpeed(100), bmh.ClockSpeed(0), bmh.ClockSpeed(99)))
-	assert.False(t, checkRangeClockSpeed(bmh.ClockSpeed(0), bmh.ClockSpeed(9), bmh.ClockSpeed(99)))
-}
-
 func TestCheckCPUSpeed(t *testing.T) {
 	testCases := []struct {
 		Scenario string
@@ -75,6 +67,33 @@ func TestCheckCPUSpeed(t *testing.T) {
 			Actual:   bmh.ClockSpeed(99),
 			Expected: false,
 		},
+		{
+			Scenario: "percent-tolerance-over-max",
+			Rule: &hwcc.Cpu{
+				MaximumSpeedMHz: 3200,
+				SpeedTolerance:  "5%",
+			},
+			Actual:   bmh.ClockSpeed(3350),
+			Expected: true,
+		},
+		{
+			Scenario: "absolute-tolerance-under-min",
+			Rule: &hwcc.Cpu{
+				MinimumSpeedMHz: 2600,
+				SpeedTolerance:  "100",
+			},
+			Actual:   bmh.ClockSpeed(2512.5),
+			Expected: true,
+		},
+		{
+			Scenario: "tolerance-exceeded",
+			Rule: &hwcc.Cpu{
+				MinimumSpeedMHz: 2600,
+				SpeedTolerance:  "50",
+			},
+			Actual:   bmh.ClockSpeed(2512.5),
+			Expected: false,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Scenario, func(t *testing.T) {
@@ -218,6 +237,55 @@ func TestCPUArchitecture(t *testing.T) {
 			Actual:   "",
 			Expected: false,
 		},
+		{
+			Scenario: "alias-matched",
+			Rule: &hwcc.Cpu{
+				Architecture: "AMD64",
+			},
+			Actual:   "x86_64",
+			Expected: true,
+		},
+		{
+			Scenario: "deprecated-ias",
+			Rule: &hwcc.Cpu{
+				Architecture: "IAS",
+			},
+			Actual:   "x86_64",
+			Expected: false,
+		},
+		{
+			Scenario: "arm-alias-matched",
+			Rule: &hwcc.Cpu{
+				Architecture: "arm64",
+			},
+			Actual:   "aarch64",
+			Expected: true,
+		},
+		{
+			Scenario: "list-matched",
+			Rule: &hwcc.Cpu{
+				Architectures: []hwcc.CPUArchitecture{"x86_64", "aarch64"},
+			},
+			Actual:   "aarch64",
+			Expected: true,
+		},
+		{
+			Scenario: "list-unmatched",
+			Rule: &hwcc.Cpu{
+				Architectures: []hwcc.CPUArchitecture{"x86_64", "aarch64"},
+			},
+			Actual:   "ppc64le",
+			Expected: false,
+		},
+		{
+			Scenario: "single-and-list-matched",
+			Rule: &hwcc.Cpu{
+				Architecture:  "x86_64",
+				Architectures: []hwcc.CPUArchitecture{"ppc64le"},
+			},
+			Actual:   "ppc64le",
+			Expected: true,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Scenario, func(t *testing.T) {
@@ -241,3 +309,160 @@ func TestCPUArchitecture(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckCPUFlags(t *testing.T) {
+	hostFlags := []string{"fpu", "vme", "svm", "avx2", "pdpe1gb", "hypervisor"}
+
+	missing, found := checkCPUFlags([]string{"vmx|svm", "pdpe1gb"}, nil, hostFlags)
+	assert.Empty(t, missing)
+	assert.Empty(t, found)
+
+	missing, found = checkCPUFlags([]string{"vmx", "avx512f", "avx2"}, []string{"hypervisor", "lm"}, hostFlags)
+	assert.Equal(t, []string{"vmx", "avx512f"}, missing)
+	assert.Equal(t, []string{"hypervisor"}, found)
+}
+
+func TestCPUFlags(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Cpu
+		Expected bool
+	}{
+		{
+			Scenario: "nil",
+			Rule:     nil,
+			Expected: true,
+		},
+		{
+			Scenario: "required-matched",
+			Rule: &hwcc.Cpu{
+				RequiredFlags: []string{"vmx|svm", "avx512f", "pdpe1gb"},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "required-missing",
+			Rule: &hwcc.Cpu{
+				RequiredFlags: []string{"svm"},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "forbidden-absent",
+			Rule: &hwcc.Cpu{
+				ForbiddenFlags: []string{"hypervisor"},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "forbidden-found",
+			Rule: &hwcc.Cpu{
+				ForbiddenFlags: []string{"avx512f"},
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Cpu: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						CPU: bmh.CPU{
+							Flags: []string{"fpu", "vmx", "avx512f", "pdpe1gb"},
+						},
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
+
+func TestCPUModel(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Cpu
+		Actual   string
+		Expected bool
+	}{
+		{
+			Scenario: "nil",
+			Rule:     nil,
+			Actual:   "Intel(R) Xeon(R) Gold 6230 CPU @ 2.10GHz",
+			Expected: true,
+		},
+		{
+			Scenario: "matched",
+			Rule: &hwcc.Cpu{
+				Model: &hwcc.StringMatcher{Value: "Gold 62[0-9]{2}", MatchType: hwcc.MatchTypeRegex},
+			},
+			Actual:   "Intel(R) Xeon(R) Gold 6230 CPU @ 2.10GHz",
+			Expected: true,
+		},
+		{
+			Scenario: "unmatched",
+			Rule: &hwcc.Cpu{
+				Model: &hwcc.StringMatcher{Value: "Gold 62[0-9]{2}", MatchType: hwcc.MatchTypeRegex},
+			},
+			Actual:   "Intel(R) Xeon(R) Silver 4214 CPU @ 2.20GHz",
+			Expected: false,
+		},
+		{
+			Scenario: "excluded",
+			Rule: &hwcc.Cpu{
+				Model: &hwcc.StringMatcher{Value: "Gold 62[0-9]{2}", MatchType: hwcc.MatchTypeRegex},
+				ExcludedModels: []hwcc.StringMatcher{
+					{Value: "Gold 6226", MatchType: hwcc.MatchTypeContains},
+				},
+			},
+			Actual:   "Intel(R) Xeon(R) Gold 6226 CPU @ 2.70GHz",
+			Expected: false,
+		},
+		{
+			Scenario: "not-excluded",
+			Rule: &hwcc.Cpu{
+				ExcludedModels: []hwcc.StringMatcher{
+					{Value: "Gold 6226", MatchType: hwcc.MatchTypeContains},
+				},
+			},
+			Actual:   "Intel(R) Xeon(R) Gold 6230 CPU @ 2.10GHz",
+			Expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Cpu: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						CPU: bmh.CPU{
+							Model: tc.Actual,
+						},
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
+
+func TestCanonicalArch(t *testing.T) {
+	assert.Equal(t, "x86_64", canonicalArch("AMD64"))
+	assert.Equal(t, "x86_64", canonicalArch("x86_64"))
+	assert.Equal(t, "aarch64", canonicalArch("arm64"))
+	assert.Equal(t, "ppc64le", canonicalArch("ppc64le"))
+	assert.Equal(t, "x86", canonicalArch("i686"))
+}
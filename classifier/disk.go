@@ -1,7 +1,11 @@
 package classifier
 
 import (
+	"path"
+	"strings"
+
 	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
 )
@@ -12,32 +16,35 @@ func checkDisks(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) b
 		return true
 	}
 
+	disks := filterDisksByType(diskDetails.Type, host.Status.HardwareDetails.Storage)
+
 	ok := checkRangeInt(
 		diskDetails.MinimumCount,
 		diskDetails.MaximumCount,
-		len(host.Status.HardwareDetails.Storage),
+		len(disks),
 	)
 	log.Info("DiskCount",
 		"host", host.Name,
 		"profile", profile.Name,
 		"namespace", host.Namespace,
+		"type", diskDetails.Type,
 		"minCount", diskDetails.MinimumCount,
 		"maxCount", diskDetails.MaximumCount,
-		"actualCount", len(host.Status.HardwareDetails.Storage),
+		"actualCount", len(disks),
 		"ok", ok,
 	)
 	if !ok {
 		return false
 	}
 
-	for i, disk := range host.Status.HardwareDetails.Storage {
+	for i, disk := range disks {
 
 		// The disk size is reported on the host in bytes and the
-		// classification rule is given in GB, so we have to convert
-		// the values to the same units. Reducing bytes to GiB loses
-		// detail, so we convert GB to bytes.
-		minSize := bmh.Capacity(diskDetails.MinimumIndividualSizeGB) * bmh.GigaByte
-		maxSize := bmh.Capacity(diskDetails.MaximumIndividualSizeGB) * bmh.GigaByte
+		// classification rule is given as a quantity or in GB, so we
+		// have to convert the values to the same units. Reducing bytes
+		// to GB loses detail, so we convert the rule to bytes.
+		minSize := getCapacity(diskDetails.MinimumIndividualSize, diskDetails.MinimumIndividualSizeGB, bmh.GigaByte)
+		maxSize := getCapacity(diskDetails.MaximumIndividualSize, diskDetails.MaximumIndividualSizeGB, bmh.GigaByte)
 
 		ok := checkRangeCapacity(
 			minSize,
@@ -58,11 +65,166 @@ func checkDisks(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) b
 		if !ok {
 			return false
 		}
+
+		ok = checkStringMatcher(diskDetails.Vendor, disk.Vendor) &&
+			checkStringMatcher(diskDetails.Model, disk.Model)
+		log.Info("DiskModel",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"vendor", diskDetails.Vendor,
+			"model", diskDetails.Model,
+			"actualVendor", disk.Vendor,
+			"actualModel", disk.Model,
+			"diskNum", i,
+			"diskName", disk.Name,
+			"ok", ok,
+		)
+		if !ok {
+			return false
+		}
+
+		if serialNumberListSet(diskDetails.AllowedSerialNumbers) &&
+			!serialNumberInList(diskDetails.AllowedSerialNumbers, disk.SerialNumber) {
+			log.Info("DiskSerialNumber",
+				"host", host.Name,
+				"profile", profile.Name,
+				"namespace", host.Namespace,
+				"actualSerialNumber", disk.SerialNumber,
+				"diskNum", i,
+				"diskName", disk.Name,
+				"ok", false,
+			)
+			return false
+		}
+	}
+
+	for i, disk := range host.Status.HardwareDetails.Storage {
+		if checkAnyStringMatcher(diskDetails.DeniedModels, disk.Model) {
+			log.Info("DiskDeniedModel",
+				"host", host.Name,
+				"profile", profile.Name,
+				"namespace", host.Namespace,
+				"actualModel", disk.Model,
+				"diskNum", i,
+				"diskName", disk.Name,
+				"ok", false,
+			)
+			return false
+		}
+		if serialNumberInList(diskDetails.DeniedSerialNumbers, disk.SerialNumber) {
+			log.Info("DiskDeniedSerialNumber",
+				"host", host.Name,
+				"profile", profile.Name,
+				"namespace", host.Namespace,
+				"actualSerialNumber", disk.SerialNumber,
+				"diskNum", i,
+				"diskName", disk.Name,
+				"ok", false,
+			)
+			return false
+		}
+	}
+
+	minTotalSize := getCapacity(diskDetails.MinimumTotalSize, diskDetails.MinimumTotalSizeGB, bmh.GigaByte)
+	maxTotalSize := getCapacity(diskDetails.MaximumTotalSize, diskDetails.MaximumTotalSizeGB, bmh.GigaByte)
+	totalSize := sumDiskSizes(disks)
+
+	ok = checkRangeCapacity(
+		minTotalSize,
+		maxTotalSize,
+		totalSize,
+	)
+	log.Info("DiskTotalSize",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"type", diskDetails.Type,
+		"minTotalSize", minTotalSize,
+		"maxTotalSize", maxTotalSize,
+		"actualTotalSize", totalSize,
+		"ok", ok,
+	)
+	if !ok {
+		return false
+	}
+
+	for i := range diskDetails.Groups {
+		group := &diskDetails.Groups[i]
+
+		groupDisks := []bmh.Storage{}
+		for j := range host.Status.HardwareDetails.Storage {
+			if checkDiskGroup(group, &host.Status.HardwareDetails.Storage[j]) {
+				groupDisks = append(groupDisks, host.Status.HardwareDetails.Storage[j])
+			}
+		}
+
+		ok := checkRangeInt(
+			group.MinimumCount,
+			group.MaximumCount,
+			len(groupDisks),
+		)
+		log.Info("DiskGroup",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"groupNum", i,
+			"minCount", group.MinimumCount,
+			"maxCount", group.MaximumCount,
+			"actualCount", len(groupDisks),
+			"ok", ok,
+		)
+		if !ok {
+			return false
+		}
+
+		minTotalSize := getCapacity(group.MinimumTotalSize, group.MinimumTotalSizeGB, bmh.GigaByte)
+		maxTotalSize := getCapacity(group.MaximumTotalSize, group.MaximumTotalSizeGB, bmh.GigaByte)
+		totalSize := sumDiskSizes(groupDisks)
+
+		ok = checkRangeCapacity(
+			minTotalSize,
+			maxTotalSize,
+			totalSize,
+		)
+		log.Info("DiskGroupTotalSize",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"groupNum", i,
+			"minTotalSize", minTotalSize,
+			"maxTotalSize", maxTotalSize,
+			"actualTotalSize", totalSize,
+			"ok", ok,
+		)
+		if !ok {
+			return false
+		}
 	}
 
 	return true
 }
 
+// checkDiskGroup checks whether the disk passes the filters of the
+// group
+func checkDiskGroup(group *hwcc.DiskGroup, disk *bmh.Storage) bool {
+	if group.Type != "" && group.Type != getDiskType(disk) {
+		return false
+	}
+	minSize := getCapacity(group.MinimumIndividualSize, group.MinimumIndividualSizeGB, bmh.GigaByte)
+	maxSize := getCapacity(group.MaximumIndividualSize, group.MaximumIndividualSizeGB, bmh.GigaByte)
+	if !checkRangeCapacity(minSize, maxSize, disk.SizeBytes) {
+		return false
+	}
+	if !checkStringMatcher(group.Vendor, disk.Vendor) {
+		return false
+	}
+	if !checkStringMatcher(group.Model, disk.Model) {
+		return false
+	}
+	return true
+}
+
 func checkRangeCapacity(min, max, count bmh.Capacity) bool {
 	if min > 0 && count < min {
 		return false
@@ -72,3 +234,48 @@ func checkRangeCapacity(min, max, count bmh.Capacity) bool {
 	}
 	return true
 }
+
+// getCapacity returns the size given as a quantity in bytes, falling
+// back to the size given as a whole number of units
+func getCapacity(quantity *resource.Quantity, size int64, unit bmh.Capacity) bmh.Capacity {
+	if quantity != nil {
+		return bmh.Capacity(quantity.Value())
+	}
+	return bmh.Capacity(size) * unit
+}
+
+// sumDiskSizes returns the raw capacity of all of the disks
+func sumDiskSizes(disks []bmh.Storage) bmh.Capacity {
+	var total bmh.Capacity
+	for _, disk := range disks {
+		total += disk.SizeBytes
+	}
+	return total
+}
+
+// getDiskType derives the type of the disk from the rotational flag
+// and the device name reported for the host
+func getDiskType(disk *bmh.Storage) hwcc.DiskType {
+	if strings.HasPrefix(path.Base(disk.Name), "nvme") {
+		return hwcc.DiskTypeNVME
+	}
+	if disk.Rotational {
+		return hwcc.DiskTypeHDD
+	}
+	return hwcc.DiskTypeSSD
+}
+
+// filterDisksByType returns the disks of the given type, or all of the
+// disks if no type is given
+func filterDisksByType(diskType hwcc.DiskType, disks []bmh.Storage) []bmh.Storage {
+	if diskType == "" {
+		return disks
+	}
+	filtered := []bmh.Storage{}
+	for i := range disks {
+		if getDiskType(&disks[i]) == diskType {
+			filtered = append(filtered, disks[i])
+		}
+	}
+	return filtered
+}
@@ -187,3 +187,502 @@ func TestCheckDiskSize(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDiskType(t *testing.T) {
+	assert.Equal(t, hwcc.DiskTypeHDD, getDiskType(&bmh.Storage{Name: "/dev/sda", Rotational: true}))
+	assert.Equal(t, hwcc.DiskTypeSSD, getDiskType(&bmh.Storage{Name: "/dev/sdb"}))
+	assert.Equal(t, hwcc.DiskTypeNVME, getDiskType(&bmh.Storage{Name: "/dev/nvme0n1"}))
+	assert.Equal(t, hwcc.DiskTypeNVME, getDiskType(&bmh.Storage{Name: "nvme1n1"}))
+}
+
+func TestCheckDiskType(t *testing.T) {
+	disks := []bmh.Storage{
+		{
+			Name:       "/dev/sda",
+			Rotational: true,
+			SizeBytes:  4000 * bmh.GigaByte,
+		},
+		{
+			Name:       "/dev/sdb",
+			Rotational: true,
+			SizeBytes:  4000 * bmh.GigaByte,
+		},
+		{
+			Name:      "/dev/sdc",
+			SizeBytes: 480 * bmh.GigaByte,
+		},
+		{
+			Name:      "/dev/nvme0n1",
+			SizeBytes: 1600 * bmh.GigaByte,
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Disk
+		Expected bool
+	}{
+		{
+			Scenario: "hdd-count-matched",
+			Rule: &hwcc.Disk{
+				Type:         hwcc.DiskTypeHDD,
+				MinimumCount: 2,
+				MaximumCount: 2,
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "ssd-count-unmatched",
+			Rule: &hwcc.Disk{
+				Type:         hwcc.DiskTypeSSD,
+				MinimumCount: 2,
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "nvme-size-matched",
+			Rule: &hwcc.Disk{
+				Type:                    hwcc.DiskTypeNVME,
+				MinimumCount:            1,
+				MinimumIndividualSizeGB: 1000,
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "hdd-size-unmatched",
+			Rule: &hwcc.Disk{
+				Type:                    hwcc.DiskTypeHDD,
+				MaximumIndividualSizeGB: 2000,
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "any-type-size-unmatched",
+			Rule: &hwcc.Disk{
+				MinimumIndividualSizeGB: 1000,
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Disk: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						Storage: disks,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
+
+func TestCheckDiskGroups(t *testing.T) {
+	// A Ceph node with a small boot SSD and six large data disks.
+	disks := []bmh.Storage{
+		{
+			Name:      "/dev/sda",
+			Vendor:    "SAMSUNG",
+			SizeBytes: 240 * bmh.GigaByte,
+		},
+	}
+	for i := 0; i < 6; i++ {
+		disks = append(disks, bmh.Storage{
+			Name:       fmt.Sprintf("/dev/sd%c", 'b'+i),
+			Vendor:     "SEAGATE",
+			Rotational: true,
+			SizeBytes:  8000 * bmh.GigaByte,
+		})
+	}
+
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Disk
+		Expected bool
+	}{
+		{
+			Scenario: "all-groups-matched",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						MinimumCount:            1,
+						MaximumCount:            2,
+						MinimumIndividualSizeGB: 200,
+						MaximumIndividualSizeGB: 500,
+					},
+					{
+						MinimumCount:            6,
+						MinimumIndividualSizeGB: 4000,
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "one-group-unmatched",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						MinimumCount:            1,
+						MinimumIndividualSizeGB: 200,
+						MaximumIndividualSizeGB: 500,
+					},
+					{
+						MinimumCount:            8,
+						MinimumIndividualSizeGB: 4000,
+					},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "type-and-vendor-matched",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						MinimumCount: 1,
+						MaximumCount: 1,
+						Type:         hwcc.DiskTypeSSD,
+						Vendor:       &hwcc.StringMatcher{Value: "SAMSUNG"},
+					},
+					{
+						MinimumCount: 6,
+						Type:         hwcc.DiskTypeHDD,
+						Vendor:       &hwcc.StringMatcher{Value: "SEAGATE"},
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "vendor-unmatched",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						MinimumCount: 1,
+						Vendor:       &hwcc.StringMatcher{Value: "INTEL"},
+					},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "group-over-max",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						MaximumCount: 4,
+						Type:         hwcc.DiskTypeHDD,
+					},
+				},
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Disk: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						Storage: disks,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
+
+func TestCheckDiskTotalSize(t *testing.T) {
+	disks := []bmh.Storage{
+		{
+			Name:      "/dev/sda",
+			SizeBytes: 480 * bmh.GigaByte,
+		},
+		{
+			Name:       "/dev/sdb",
+			Rotational: true,
+			SizeBytes:  8000 * bmh.GigaByte,
+		},
+		{
+			Name:       "/dev/sdc",
+			Rotational: true,
+			SizeBytes:  8000 * bmh.GigaByte,
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Disk
+		Expected bool
+	}{
+		{
+			Scenario: "within-min",
+			Rule: &hwcc.Disk{
+				MinimumTotalSizeGB: 16000,
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "over-max",
+			Rule: &hwcc.Disk{
+				MaximumTotalSizeGB: 16000,
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "type-within-max",
+			Rule: &hwcc.Disk{
+				Type:               hwcc.DiskTypeHDD,
+				MinimumTotalSizeGB: 16000,
+				MaximumTotalSizeGB: 16000,
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "group-within-min",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						MinimumIndividualSizeGB: 4000,
+						MinimumTotalSizeGB:      16000,
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "group-under-min",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						Type:               hwcc.DiskTypeSSD,
+						MinimumTotalSizeGB: 1000,
+					},
+				},
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Disk: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						Storage: disks,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
+
+func TestCheckDiskModel(t *testing.T) {
+	disks := []bmh.Storage{
+		{
+			Name:      "/dev/sda",
+			Vendor:    "ATA",
+			Model:     "SSDSC2KB480G8",
+			SizeBytes: 480 * bmh.GigaByte,
+		},
+		{
+			Name:      "/dev/nvme0n1",
+			Vendor:    "INTEL",
+			Model:     "SSDPE2KX040T8",
+			SizeBytes: 4000 * bmh.GigaByte,
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Disk
+		Expected bool
+	}{
+		{
+			Scenario: "model-matched",
+			Rule: &hwcc.Disk{
+				Model: &hwcc.StringMatcher{Value: "^SSD(SC2KB|PE2KX)", MatchType: hwcc.MatchTypeRegex},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "model-unmatched",
+			Rule: &hwcc.Disk{
+				Model: &hwcc.StringMatcher{Value: "SSDSC2KB", MatchType: hwcc.MatchTypePrefix},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "type-vendor-matched",
+			Rule: &hwcc.Disk{
+				Type:   hwcc.DiskTypeNVME,
+				Vendor: &hwcc.StringMatcher{Value: "INTEL"},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "vendor-unmatched",
+			Rule: &hwcc.Disk{
+				Vendor: &hwcc.StringMatcher{Value: "INTEL"},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "denied-model",
+			Rule: &hwcc.Disk{
+				Type: hwcc.DiskTypeNVME,
+				DeniedModels: []hwcc.StringMatcher{
+					{Value: "Samsung SSD 970", MatchType: hwcc.MatchTypePrefix},
+					{Value: "SSDSC2KB480G8"},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "no-denied-model",
+			Rule: &hwcc.Disk{
+				DeniedModels: []hwcc.StringMatcher{
+					{Value: "Samsung SSD 970", MatchType: hwcc.MatchTypePrefix},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "group-model-matched",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						MinimumCount: 1,
+						MaximumCount: 1,
+						Model:        &hwcc.StringMatcher{Value: "SSDPE2KX", MatchType: hwcc.MatchTypePrefix},
+					},
+				},
+			},
+			Expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Disk: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						Storage: disks,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
+
+func TestCheckDiskSizeQuantity(t *testing.T) {
+	disks := []bmh.Storage{
+		{
+			Name:      "/dev/sda",
+			SizeBytes: 1920383410176,
+		},
+		{
+			Name:      "/dev/sdb",
+			SizeBytes: 1920383410176,
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Disk
+		Expected bool
+	}{
+		{
+			Scenario: "decimal-within-min",
+			Rule: &hwcc.Disk{
+				MinimumIndividualSize: quantity("1.92T"),
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "binary-under-min",
+			Rule: &hwcc.Disk{
+				MinimumIndividualSize: quantity("1.92Ti"),
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "quantity-precedence",
+			Rule: &hwcc.Disk{
+				MaximumIndividualSizeGB: 1000,
+				MaximumIndividualSize:   quantity("2T"),
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "total-over-max",
+			Rule: &hwcc.Disk{
+				MaximumTotalSize: quantity("3.5T"),
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "group-matched",
+			Rule: &hwcc.Disk{
+				Groups: []hwcc.DiskGroup{
+					{
+						MinimumCount:          2,
+						MinimumIndividualSize: quantity("1.5T"),
+						MaximumIndividualSize: quantity("2Ti"),
+						MinimumTotalSize:      quantity("3.8T"),
+					},
+				},
+			},
+			Expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Disk: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						Storage: disks,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package classifier
+
+import (
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// expression is the common form of the characteristics at every level
+// of nesting. An expression referring to a block which is not defined
+// is missing and never matches.
+type expression struct {
+	block   hwcc.CharacteristicsBlock
+	allOf   []expression
+	anyOf   []expression
+	not     *expression
+	missing bool
+}
+
+// characteristicsExpression returns the expression for the
+// characteristics of the profile, with the named blocks resolved
+func characteristicsExpression(characteristics *hwcc.HardwareCharacteristics) expression {
+	result := expression{
+		block: hwcc.CharacteristicsBlock{
+			Cpu:          characteristics.Cpu,
+			Disk:         characteristics.Disk,
+			Nic:          characteristics.Nic,
+			Ram:          characteristics.Ram,
+			SystemVendor: characteristics.SystemVendor,
+			Firmware:     characteristics.Firmware,
+			Hostname:     characteristics.Hostname,
+		},
+	}
+	for i := range characteristics.AllOf {
+		result.allOf = append(result.allOf, nestedExpression(characteristics, &characteristics.AllOf[i]))
+	}
+	for i := range characteristics.AnyOf {
+		result.anyOf = append(result.anyOf, nestedExpression(characteristics, &characteristics.AnyOf[i]))
+	}
+	if characteristics.Not != nil {
+		not := nestedExpression(characteristics, characteristics.Not)
+		result.not = &not
+	}
+	return result
+}
+
+func nestedExpression(characteristics *hwcc.HardwareCharacteristics, nested *hwcc.CharacteristicsExpression) expression {
+	result := blockExpression(characteristics, nested.Block)
+	for _, name := range nested.AnyOf {
+		result.anyOf = append(result.anyOf, blockExpression(characteristics, name))
+	}
+	return result
+}
+
+func blockExpression(characteristics *hwcc.HardwareCharacteristics, name string) expression {
+	block := namedBlock(characteristics, name)
+	if block == nil {
+		return expression{missing: true}
+	}
+	return expression{block: *block}
+}
+
+// namedBlock returns the block of the characteristics with the name,
+// an empty block if the name is empty, or nil if there is no such
+// block
+func namedBlock(characteristics *hwcc.HardwareCharacteristics, name string) *hwcc.CharacteristicsBlock {
+	if name == "" {
+		return &hwcc.CharacteristicsBlock{}
+	}
+	for i := range characteristics.Blocks {
+		if characteristics.Blocks[i].Name == name {
+			return &characteristics.Blocks[i].CharacteristicsBlock
+		}
+	}
+	return nil
+}
+
+// checkExpression checks the characteristics of the block and the
+// combinations nested in the expression
+func checkExpression(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost, expr *expression, fldPath *field.Path) bool {
+	if expr.missing || !checkBlock(profile, host, &expr.block) {
+		return false
+	}
+
+	for i := range expr.allOf {
+		branch := fldPath.Child("allOf").Index(i)
+		ok := checkExpression(profile, host, &expr.allOf[i], branch)
+		log.Info("AllOf",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"branch", branch.String(),
+			"ok", ok,
+		)
+		if !ok {
+			return false
+		}
+	}
+
+	if len(expr.anyOf) > 0 {
+		matched := ""
+		for i := range expr.anyOf {
+			branch := fldPath.Child("anyOf").Index(i)
+			if checkExpression(profile, host, &expr.anyOf[i], branch) {
+				matched = branch.String()
+				break
+			}
+		}
+		ok := matched != ""
+		log.Info("AnyOf",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"branch", matched,
+			"ok", ok,
+		)
+		if !ok {
+			return false
+		}
+	}
+
+	if expr.not != nil {
+		branch := fldPath.Child("not")
+		ok := !checkExpression(profile, host, expr.not, branch)
+		log.Info("Not",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"branch", branch.String(),
+			"ok", ok,
+		)
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkNamedBlock runs the checks of the characteristics in the named
+// block of the profile, a block which is not defined does not match
+func checkNamedBlock(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost, name string) bool {
+	block := namedBlock(&profile.Spec.HardwareCharacteristics, name)
+	return block != nil && checkBlock(profile, host, block)
+}
+
+// checkBlock runs the checks of the characteristics in the block. The
+// checks read the characteristics from the profile, so they are given
+// a copy of the profile holding the block.
+func checkBlock(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost, block *hwcc.CharacteristicsBlock) bool {
+	blockProfile := *profile
+	blockProfile.Spec.HardwareCharacteristics = hwcc.HardwareCharacteristics{
+		Cpu:          block.Cpu,
+		Disk:         block.Disk,
+		Nic:          block.Nic,
+		Ram:          block.Ram,
+		SystemVendor: block.SystemVendor,
+		Firmware:     block.Firmware,
+		Hostname:     block.Hostname,
+	}
+
+	if !checkHostname(&blockProfile, host) {
+		return false
+	}
+	if !checkSystemVendor(&blockProfile, host) {
+		return false
+	}
+	if !checkFirmware(&blockProfile, host) {
+		return false
+	}
+	if !checkCPU(&blockProfile, host) {
+		return false
+	}
+	if !checkRAM(&blockProfile, host) {
+		return false
+	}
+	if !checkNICs(&blockProfile, host) {
+		return false
+	}
+	if !checkDisks(&blockProfile, host) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package classifier
+
+import (
+	"testing"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestCheckExpression(t *testing.T) {
+	blocks := []hwcc.NamedCharacteristicsBlock{
+		{
+			Name: "dell",
+			CharacteristicsBlock: hwcc.CharacteristicsBlock{
+				SystemVendor: &hwcc.SystemVendor{
+					Manufacturer: "Dell Inc.",
+					ProductName:  "PowerEdge R640",
+				},
+			},
+		},
+		{
+			Name: "hpe",
+			CharacteristicsBlock: hwcc.CharacteristicsBlock{
+				SystemVendor: &hwcc.SystemVendor{
+					Manufacturer: "HPE",
+					ProductName:  "ProLiant DL360",
+				},
+			},
+		},
+		{
+			Name: "big-ram",
+			CharacteristicsBlock: hwcc.CharacteristicsBlock{
+				Ram: &hwcc.Ram{
+					MinimumSizeGB: 256,
+				},
+			},
+		},
+		{
+			Name: "many-cpus",
+			CharacteristicsBlock: hwcc.CharacteristicsBlock{
+				Cpu: &hwcc.Cpu{MinimumCount: 32},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+		Rule     hwcc.HardwareCharacteristics
+		Expected bool
+	}{
+		{
+			Scenario: "any-of-matched",
+			Rule: hwcc.HardwareCharacteristics{
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{Block: "hpe"},
+					{Block: "dell"},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "any-of-unmatched",
+			Rule: hwcc.HardwareCharacteristics{
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{Block: "hpe"},
+					{Block: "big-ram"},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "any-of-with-common-characteristics",
+			Rule: hwcc.HardwareCharacteristics{
+				Ram: &hwcc.Ram{
+					MinimumSizeGB: 256,
+				},
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{Block: "hpe"},
+					{Block: "dell"},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "all-of-matched",
+			Rule: hwcc.HardwareCharacteristics{
+				AllOf: []hwcc.CharacteristicsExpression{
+					{AnyOf: []string{"hpe", "dell"}},
+					{Block: "many-cpus"},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "all-of-unmatched",
+			Rule: hwcc.HardwareCharacteristics{
+				AllOf: []hwcc.CharacteristicsExpression{
+					{AnyOf: []string{"hpe", "dell"}},
+					{AnyOf: []string{"hpe", "big-ram"}},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "block-and-any-of",
+			Rule: hwcc.HardwareCharacteristics{
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{Block: "many-cpus", AnyOf: []string{"hpe", "dell"}},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "not-matched",
+			Rule: hwcc.HardwareCharacteristics{
+				Not: &hwcc.CharacteristicsExpression{
+					AnyOf: []string{"hpe", "big-ram"},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "not-unmatched",
+			Rule: hwcc.HardwareCharacteristics{
+				Not: &hwcc.CharacteristicsExpression{
+					Block: "dell",
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "unreferenced-blocks",
+			Rule:     hwcc.HardwareCharacteristics{},
+			Expected: true,
+		},
+		{
+			Scenario: "missing-block",
+			Rule: hwcc.HardwareCharacteristics{
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{Block: "dell"},
+					{Block: "lenovo"},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "nested-invalid-pattern",
+			Rule: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{
+						Name: "invalid-hostname",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Hostname: &hwcc.Hostname{Name: "r12-["},
+						},
+					},
+				},
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{AnyOf: []string{"invalid-hostname", "dell"}},
+				},
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			tc.Rule.Blocks = append(tc.Rule.Blocks, blocks...)
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: tc.Rule,
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						SystemVendor: bmh.HardwareSystemVendor{
+							Manufacturer: "Dell Inc.",
+							ProductName:  "PowerEdge R640",
+						},
+						CPU: bmh.CPU{
+							Count: 40,
+						},
+						RAMMebibytes: 192 * 1024,
+					},
+				},
+			}
+			// Invalid profiles are rejected before they are matched,
+			// as done by the controller.
+			matched := ValidateProfile(&profile) == nil && ProfileMatchesHost(&profile, &host)
+			assert.Equal(t, tc.Expected, matched)
+		})
+	}
+}
@@ -2,8 +2,10 @@ package classifier
 
 import (
 	"fmt"
-	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	"strings"
+	"time"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
 )
@@ -15,14 +17,20 @@ func checkFirmware(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost
 		return true
 	}
 
-	ok := checkString(firmwareDetails.BIOS.Vendor, host.Status.HardwareDetails.Firmware.BIOS.Vendor)
+	ok := checkVendor(firmwareDetails.BIOS.Vendor,
+		firmwareDetails.BIOS.VendorMatchType,
+		firmwareDetails.BIOS.ExcludedVendors,
+		host.Status.HardwareDetails.Firmware.BIOS.Vendor)
 
 	log.Info("Firmware",
 		"host", host.Name,
 		"profile", profile.Name,
 		"namespace", host.Namespace,
 		"vendor", firmwareDetails.BIOS.Vendor,
+		"vendor match type", firmwareDetails.BIOS.VendorMatchType,
+		"excluded vendors", firmwareDetails.BIOS.ExcludedVendors,
 		"actualVendor", host.Status.HardwareDetails.Firmware.BIOS.Vendor,
+		"canonicalVendor", canonicalVendor(host.Status.HardwareDetails.Firmware.BIOS.Vendor),
 		"ok", ok,
 	)
 	if !ok {
@@ -31,6 +39,7 @@ func checkFirmware(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost
 
 	ok = checkVersion(firmwareDetails.BIOS.MinorVersion,
 		firmwareDetails.BIOS.MajorVersion,
+		firmwareDetails.BIOS.VersionConstraint,
 		host.Status.HardwareDetails.Firmware.BIOS.Version)
 
 	log.Info("Firmware",
@@ -39,6 +48,7 @@ func checkFirmware(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost
 		"namespace", host.Namespace,
 		"minor version", firmwareDetails.BIOS.MinorVersion,
 		"major version", firmwareDetails.BIOS.MajorVersion,
+		"version constraint", firmwareDetails.BIOS.VersionConstraint,
 		"actualVersion", host.Status.HardwareDetails.Firmware.BIOS.Version,
 		"ok", ok,
 	)
@@ -46,35 +56,120 @@ func checkFirmware(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost
 		return false
 	}
 
+	ok = checkReleaseDate(firmwareDetails.BIOS.MinimumReleaseDate,
+		firmwareDetails.BIOS.MaximumReleaseDate,
+		host.Status.HardwareDetails.Firmware.BIOS.Date)
+
+	log.Info("Firmware",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"minReleaseDate", firmwareDetails.BIOS.MinimumReleaseDate,
+		"maxReleaseDate", firmwareDetails.BIOS.MaximumReleaseDate,
+		"actualReleaseDate", host.Status.HardwareDetails.Firmware.BIOS.Date,
+		"ok", ok,
+	)
+	if !ok {
+		return false
+	}
+
 	return true
 }
 
-// Check the version range
-func checkVersion(minorVersion, majorVersion, hostVersion string) bool {
+// biosDateLayouts are the formats of the bios release dates reported
+// by the inspector, the SMBIOS specification uses mm/dd/yyyy
+var biosDateLayouts = []string{
+	"2006-01-02",
+	"01/02/2006",
+	"01/02/06",
+	"2006/01/02",
+	"20060102",
+	"Jan 2 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+}
 
-	if minorVersion != "" && majorVersion != "" {
+// parseBIOSDate parses the date in any of the known layouts
+func parseBIOSDate(date string) (time.Time, error) {
+	date = strings.TrimSpace(date)
+	for _, layout := range biosDateLayouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown date format %q", date)
+}
 
-		convertedMinorVersion := appendZeros(minorVersion, 4)
-		convertedMajorVersion := appendZeros(majorVersion, 4)
-		convertedHostVersion := appendZeros(hostVersion, 4)
+// checkReleaseDate checks the host bios release date is within the
+// range of dates
+func checkReleaseDate(minDate, maxDate, hostDate string) bool {
+	if minDate == "" && maxDate == "" {
+		return true
+	}
 
-		if convertedMinorVersion != "" && convertedMajorVersion != "" && convertedHostVersion != "" {
-			if convertedMinorVersion > convertedHostVersion || convertedMajorVersion < convertedHostVersion {
-				return false
-			}
+	actual, err := parseBIOSDate(hostDate)
+	if err != nil {
+		log.Info("could not parse host bios date", "date", hostDate, "error", err.Error())
+		return false
+	}
+
+	if minDate != "" {
+		min, err := parseBIOSDate(minDate)
+		if err != nil {
+			log.Error(err, "invalid minimum release date")
+			return false
+		}
+		if actual.Before(min) {
+			return false
+		}
+	}
+	if maxDate != "" {
+		max, err := parseBIOSDate(maxDate)
+		if err != nil {
+			log.Error(err, "invalid maximum release date")
+			return false
+		}
+		if actual.After(max) {
+			return false
 		}
 	}
 	return true
 }
 
-// Append zero to make it a number
-func appendZeros(version string, zeros int) string {
-	splitVersions := strings.Split(version, ".")
-	appendVersionString := ""
+// checkVersion checks the host version against the version range and
+// the version constraint expression. The minor and major versions are
+// the lowest and highest accepted versions.
+func checkVersion(minorVersion, majorVersion, constraint, hostVersion string) bool {
+	expression := []string{}
+	if minorVersion != "" {
+		expression = append(expression, ">="+minorVersion)
+	}
+	if majorVersion != "" {
+		expression = append(expression, "<="+majorVersion)
+	}
+	if constraint != "" {
+		expression = append(expression, constraint)
+	}
+	if len(expression) == 0 {
+		return true
+	}
 
-	for _, value := range splitVersions {
-		appendVersionString += fmt.Sprintf("%0*s", zeros, value)
+	constraints, err := parseVersionConstraints(strings.Join(expression, ","))
+	if err != nil {
+		log.Error(err, "invalid version constraint")
+		return false
 	}
 
-	return appendVersionString
+	version, err := parseVersion(hostVersion)
+	if err != nil {
+		log.Info("could not parse host version", "version", hostVersion, "error", err.Error())
+		return false
+	}
+
+	for _, c := range constraints {
+		if !c.check(version) {
+			return false
+		}
+	}
+	return true
 }
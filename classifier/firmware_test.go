@@ -2,6 +2,7 @@ package classifier
 
 import (
 	"testing"
+	"time"
 
 	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	"github.com/stretchr/testify/assert"
@@ -306,3 +307,104 @@ func TestFirmware(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBIOSDate(t *testing.T) {
+	expected := time.Date(2021, time.March, 15, 0, 0, 0, 0, time.UTC)
+	for _, date := range []string{"03/15/2021", "2021-03-15", "03/15/21", "2021/03/15", "20210315", "Mar 15 2021", " 03/15/2021 "} {
+		actual, err := parseBIOSDate(date)
+		assert.NoError(t, err, date)
+		assert.Equal(t, expected, actual, date)
+	}
+	_, err := parseBIOSDate("15/03/2021")
+	assert.Error(t, err)
+}
+
+func TestCheckFirmwareReleaseDate(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Firmware
+		Actual   string
+		Expected bool
+	}{
+		{
+			Scenario: "no-min-max",
+			Rule:     &hwcc.Firmware{},
+			Actual:   "03/15/2021",
+			Expected: true,
+		},
+		{
+			Scenario: "within-min",
+			Rule: &hwcc.Firmware{
+				BIOS: hwcc.BIOS{
+					MinimumReleaseDate: "2021-03-01",
+				},
+			},
+			Actual:   "03/15/2021",
+			Expected: true,
+		},
+		{
+			Scenario: "same-day",
+			Rule: &hwcc.Firmware{
+				BIOS: hwcc.BIOS{
+					MinimumReleaseDate: "2021-03-15",
+					MaximumReleaseDate: "2021-03-15",
+				},
+			},
+			Actual:   "03/15/2021",
+			Expected: true,
+		},
+		{
+			Scenario: "under-min",
+			Rule: &hwcc.Firmware{
+				BIOS: hwcc.BIOS{
+					MinimumReleaseDate: "2021-03-01",
+				},
+			},
+			Actual:   "11/27/2020",
+			Expected: false,
+		},
+		{
+			Scenario: "over-max",
+			Rule: &hwcc.Firmware{
+				BIOS: hwcc.BIOS{
+					MaximumReleaseDate: "2020-12-31",
+				},
+			},
+			Actual:   "2021-03-15",
+			Expected: false,
+		},
+		{
+			Scenario: "empty-host-details",
+			Rule: &hwcc.Firmware{
+				BIOS: hwcc.BIOS{
+					MinimumReleaseDate: "2021-03-01",
+				},
+			},
+			Actual:   "",
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Firmware: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						Firmware: bmh.Firmware{
+							BIOS: bmh.BIOS{
+								Date: tc.Actual,
+							},
+						},
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package classifier
+
+import (
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// checkHostname checks the host name reported in the hardware details
+// against the name pattern and the excluded names of the profile
+func checkHostname(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) bool {
+	hostnameDetails := profile.Spec.HardwareCharacteristics.Hostname
+	if hostnameDetails == nil {
+		return true
+	}
+
+	hostname := host.Status.HardwareDetails.Hostname
+	ok := checkMatchType(hostnameDetails.Name,
+		hostnameDetails.MatchType,
+		hwcc.MatchTypeGlob,
+		hostname) &&
+		!checkAnyStringMatcher(hostnameDetails.ExcludedNames, hostname)
+	log.Info("Hostname",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"name", hostnameDetails.Name,
+		"matchType", hostnameDetails.MatchType,
+		"excludedNames", hostnameDetails.ExcludedNames,
+		"actualHostname", hostname,
+		"ok", ok,
+	)
+	return ok
+}
@@ -0,0 +1,107 @@
+package classifier
+
+import (
+	"testing"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestCheckHostname(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Hostname
+		Actual   string
+		Expected bool
+	}{
+		{
+			Scenario: "nil",
+			Rule:     nil,
+			Actual:   "r12-stor-07",
+			Expected: true,
+		},
+		{
+			Scenario: "glob-matched",
+			Rule: &hwcc.Hostname{
+				Name: "r12-stor-*",
+			},
+			Actual:   "r12-stor-07",
+			Expected: true,
+		},
+		{
+			Scenario: "glob-unmatched",
+			Rule: &hwcc.Hostname{
+				Name: "r12-stor-*",
+			},
+			Actual:   "r12-comp-07",
+			Expected: false,
+		},
+		{
+			Scenario: "regex-matched",
+			Rule: &hwcc.Hostname{
+				Name:      `^r1[0-9]-stor-\d+$`,
+				MatchType: hwcc.MatchTypeRegex,
+			},
+			Actual:   "r12-stor-07",
+			Expected: true,
+		},
+		{
+			Scenario: "excluded",
+			Rule: &hwcc.Hostname{
+				Name: "r12-stor-*",
+				ExcludedNames: []hwcc.StringMatcher{
+					{Value: "r12-stor-0[0-7]", MatchType: hwcc.MatchTypeGlob},
+				},
+			},
+			Actual:   "r12-stor-07",
+			Expected: false,
+		},
+		{
+			Scenario: "not-excluded",
+			Rule: &hwcc.Hostname{
+				ExcludedNames: []hwcc.StringMatcher{
+					{Value: "r12-stor-0[0-3]", MatchType: hwcc.MatchTypeGlob},
+				},
+			},
+			Actual:   "r12-stor-07",
+			Expected: true,
+		},
+		{
+			Scenario: "empty-hostname",
+			Rule: &hwcc.Hostname{
+				Name: "r12-stor-*",
+			},
+			Actual:   "",
+			Expected: false,
+		},
+		{
+			Scenario: "invalid-pattern",
+			Rule: &hwcc.Hostname{
+				Name: "r12-stor-[",
+			},
+			Actual:   "r12-stor-07",
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Hostname: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						Hostname: tc.Actual,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package classifier
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// regexCache keeps the compiled regular expressions, which are matched
+// against every host and disk
+var regexCache = newCache(1024)
+
+// compileRegex returns the compiled regular expression, compiling it
+// only the first time it is used
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.get(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.add(pattern, re)
+	return re, nil
+}
+
+// checkStringMatcher checks if the host details match the expected
+// value in the way given by the match type
+func checkStringMatcher(matcher *hwcc.StringMatcher, hostSpecific string) bool {
+	if matcher == nil {
+		return true
+	}
+
+	ok, err := matchString(matcher.Value, matcher.MatchType, hostSpecific)
+	if err != nil {
+		log.Error(err, "invalid pattern",
+			"value", matcher.Value,
+			"matchType", matcher.MatchType,
+		)
+		return false
+	}
+	return ok
+}
+
+// checkAnyStringMatcher checks if the host details match any of the
+// expected values
+func checkAnyStringMatcher(matchers []hwcc.StringMatcher, hostSpecific string) bool {
+	for i := range matchers {
+		if checkStringMatcher(&matchers[i], hostSpecific) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMatchType checks if the host details match the expected value
+// in the way given by the match type, or by the default match type if
+// none is given. An empty expected value always matches.
+func checkMatchType(expected string, matchType, defaultMatchType hwcc.MatchType, hostSpecific string) bool {
+	if expected == "" {
+		return true
+	}
+	if matchType == "" {
+		matchType = defaultMatchType
+	}
+	return checkStringMatcher(&hwcc.StringMatcher{Value: expected, MatchType: matchType}, hostSpecific)
+}
+
+// matchString compares the host details with the value, returning an
+// error if the value is not a valid pattern for the match type
+func matchString(value string, matchType hwcc.MatchType, hostSpecific string) (bool, error) {
+	switch matchType {
+	case hwcc.MatchTypePrefix:
+		return strings.HasPrefix(hostSpecific, value), nil
+	case hwcc.MatchTypeContains:
+		return strings.Contains(hostSpecific, value), nil
+	case hwcc.MatchTypeRegex:
+		re, err := compileRegex(value)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(hostSpecific), nil
+	case hwcc.MatchTypeGlob:
+		return path.Match(value, hostSpecific)
+	default:
+		return hostSpecific == value, nil
+	}
+}
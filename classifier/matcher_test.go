@@ -0,0 +1,131 @@
+package classifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestCheckStringMatcher(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Matcher  *hwcc.StringMatcher
+		Actual   string
+		Expected bool
+	}{
+		{
+			Scenario: "nil",
+			Matcher:  nil,
+			Actual:   "SSDSC2KB480G8",
+			Expected: true,
+		},
+		{
+			Scenario: "default-exact-matched",
+			Matcher:  &hwcc.StringMatcher{Value: "SSDSC2KB480G8"},
+			Actual:   "SSDSC2KB480G8",
+			Expected: true,
+		},
+		{
+			Scenario: "exact-unmatched",
+			Matcher:  &hwcc.StringMatcher{Value: "SSDSC2KB", MatchType: hwcc.MatchTypeExact},
+			Actual:   "SSDSC2KB480G8",
+			Expected: false,
+		},
+		{
+			Scenario: "prefix-matched",
+			Matcher:  &hwcc.StringMatcher{Value: "SSDSC2KB", MatchType: hwcc.MatchTypePrefix},
+			Actual:   "SSDSC2KB480G8",
+			Expected: true,
+		},
+		{
+			Scenario: "prefix-unmatched",
+			Matcher:  &hwcc.StringMatcher{Value: "480G8", MatchType: hwcc.MatchTypePrefix},
+			Actual:   "SSDSC2KB480G8",
+			Expected: false,
+		},
+		{
+			Scenario: "contains-matched",
+			Matcher:  &hwcc.StringMatcher{Value: "480G8", MatchType: hwcc.MatchTypeContains},
+			Actual:   "SSDSC2KB480G8",
+			Expected: true,
+		},
+		{
+			Scenario: "regex-matched",
+			Matcher:  &hwcc.StringMatcher{Value: "^SSDSC2K[BG]", MatchType: hwcc.MatchTypeRegex},
+			Actual:   "SSDSC2KB480G8",
+			Expected: true,
+		},
+		{
+			Scenario: "regex-unmatched",
+			Matcher:  &hwcc.StringMatcher{Value: "^MZ7", MatchType: hwcc.MatchTypeRegex},
+			Actual:   "SSDSC2KB480G8",
+			Expected: false,
+		},
+		{
+			Scenario: "glob-matched",
+			Matcher:  &hwcc.StringMatcher{Value: "SSDSC2K?480*", MatchType: hwcc.MatchTypeGlob},
+			Actual:   "SSDSC2KB480G8",
+			Expected: true,
+		},
+		{
+			Scenario: "glob-unmatched",
+			Matcher:  &hwcc.StringMatcher{Value: "SSDSC2K?480", MatchType: hwcc.MatchTypeGlob},
+			Actual:   "SSDSC2KB480G8",
+			Expected: false,
+		},
+		{
+			Scenario: "invalid-glob",
+			Matcher:  &hwcc.StringMatcher{Value: "SSDSC2K[", MatchType: hwcc.MatchTypeGlob},
+			Actual:   "SSDSC2KB480G8",
+			Expected: false,
+		},
+		{
+			Scenario: "invalid-regex",
+			Matcher:  &hwcc.StringMatcher{Value: "SSDSC2KB(", MatchType: hwcc.MatchTypeRegex},
+			Actual:   "SSDSC2KB480G8",
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, checkStringMatcher(tc.Matcher, tc.Actual))
+		})
+	}
+}
+
+func TestCheckMatchType(t *testing.T) {
+	assert.True(t, checkMatchType("", hwcc.MatchTypeExact, hwcc.MatchTypeExact, "Dell Inc."))
+	assert.True(t, checkMatchType("Dell", "", hwcc.MatchTypePrefix, "Dell Inc."))
+	assert.False(t, checkMatchType("Dell", "", hwcc.MatchTypeExact, "Dell Inc."))
+	assert.True(t, checkMatchType("Dell*", hwcc.MatchTypeGlob, hwcc.MatchTypeExact, "Dell Inc."))
+}
+
+func TestCompileRegex(t *testing.T) {
+	first, err := compileRegex("^PowerEdge R6[0-9]0$")
+	assert.NoError(t, err)
+	second, err := compileRegex("^PowerEdge R6[0-9]0$")
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+
+	_, err = compileRegex("Gold (62")
+	assert.Error(t, err)
+}
+
+func TestCache(t *testing.T) {
+	c := newCache(2)
+	c.add("a", 1)
+	c.add("b", 2)
+	value, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	// Adding to a full cache empties it.
+	c.add("c", 3)
+	_, ok = c.get("a")
+	assert.False(t, ok)
+	value, ok = c.get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+}
@@ -26,6 +26,76 @@ func checkNICs(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) bo
 		"actualCount", len(host.Status.HardwareDetails.NIC),
 		"ok", ok,
 	)
+	if !ok {
+		return false
+	}
+
+	for i := range nicDetails.Selectors {
+		selector := &nicDetails.Selectors[i]
+
+		count := 0
+		for j := range host.Status.HardwareDetails.NIC {
+			if checkNICSelector(selector, &host.Status.HardwareDetails.NIC[j]) {
+				count++
+			}
+		}
+
+		ok := checkRangeInt(
+			selector.MinimumCount,
+			selector.MaximumCount,
+			count,
+		)
+		log.Info("NICSelector",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"selectorNum", i,
+			"minCount", selector.MinimumCount,
+			"maxCount", selector.MaximumCount,
+			"actualCount", count,
+			"ok", ok,
+		)
+		if !ok {
+			return false
+		}
+	}
 
-	return ok
+	return true
+}
+
+// checkNICSelector checks whether the NIC passes the filters of the
+// selector
+func checkNICSelector(selector *hwcc.NicSelector, nic *bmh.NIC) bool {
+	if !checkRangeInt(selector.MinimumSpeedGbps, selector.MaximumSpeedGbps, nic.SpeedGbps) {
+		return false
+	}
+	if selector.PXE != nil && *selector.PXE != nic.PXE {
+		return false
+	}
+	if !checkString(selector.Name, nic.Name) {
+		return false
+	}
+	if !checkSubString(selector.Model, nic.Model) {
+		return false
+	}
+	for _, vlanID := range selector.VLANIDs {
+		if !checkNICVLAN(vlanID, nic) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkNICVLAN checks whether the VLAN is available on the NIC, either
+// as one of its tagged VLANs or as its untagged VLAN
+func checkNICVLAN(vlanID int32, nic *bmh.NIC) bool {
+	if bmh.VLANID(vlanID) == nic.VLANID {
+		return true
+	}
+	for _, vlan := range nic.VLANs {
+		if bmh.VLANID(vlanID) == vlan.ID {
+			return true
+		}
+	}
+	return false
 }
@@ -93,3 +93,152 @@ func TestCheckNICCount(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckNICSelectors(t *testing.T) {
+	pxe := true
+	nics := []bmh.NIC{
+		{
+			Name:      "eno1",
+			Model:     "0x8086 0x1572",
+			SpeedGbps: 1,
+			PXE:       true,
+			VLANID:    10,
+		},
+		{
+			Name:      "ens1f0",
+			Model:     "Mellanox ConnectX-5",
+			SpeedGbps: 25,
+			VLANs:     []bmh.VLAN{{ID: 100}, {ID: 200}},
+		},
+		{
+			Name:      "ens1f1",
+			Model:     "Mellanox ConnectX-5",
+			SpeedGbps: 25,
+			VLANs:     []bmh.VLAN{{ID: 100}},
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Nic
+		Expected bool
+	}{
+		{
+			Scenario: "no-selectors",
+			Rule:     &hwcc.Nic{},
+			Expected: true,
+		},
+		{
+			Scenario: "speed-matched",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 2, MinimumSpeedGbps: 25},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "speed-unmatched",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 3, MinimumSpeedGbps: 25},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "speed-and-pxe-matched",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 2, MinimumSpeedGbps: 25},
+					{MinimumCount: 1, PXE: &pxe},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "speed-over-max",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MaximumCount: 1, MinimumSpeedGbps: 25},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "tagged-vlans-matched",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 1, MaximumCount: 1, VLANIDs: []int32{100, 200}},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "untagged-vlan-matched",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 1, VLANIDs: []int32{10}},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "vlan-unmatched",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 1, VLANIDs: []int32{300}},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "model-and-name-matched",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 2, Model: "Mellanox"},
+					{MinimumCount: 1, Name: "ens1f1"},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "name-unmatched",
+			Rule: &hwcc.Nic{
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 1, Name: "ens1"},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "total-count-unmatched",
+			Rule: &hwcc.Nic{
+				MaximumCount: 2,
+				Selectors: []hwcc.NicSelector{
+					{MinimumCount: 1, PXE: &pxe},
+				},
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Nic: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						NIC: nics,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
@@ -13,14 +13,18 @@ func checkRAM(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) boo
 	}
 
 	// The size reported on the host is in MiB and the classification
-	// rule is given in GB, so we have to convert the values to the
-	// same units. Reducing MiB to GB loses detail, so we convert GB
-	// to MiB.
-	actualSize := host.Status.HardwareDetails.RAMMebibytes
-	minSize := ramDetails.MinimumSizeGB * 1024
-	maxSize := ramDetails.MaximumSizeGB * 1024
+	// rule is given as a quantity or in GB (really GiB), so we have to
+	// convert the values to the same units. Reducing MiB to GB loses
+	// detail and a quantity may be more precise than a MiB, so we
+	// convert everything to bytes.
+	actualSize := bmh.Capacity(host.Status.HardwareDetails.RAMMebibytes) * bmh.MebiByte
+	minSize := getCapacity(ramDetails.MinimumSize, int64(ramDetails.MinimumSizeGB), bmh.GibiByte)
+	maxSize := getCapacity(ramDetails.MaximumSize, int64(ramDetails.MaximumSizeGB), bmh.GibiByte)
 
-	ok := checkRangeInt(minSize, maxSize, actualSize)
+	// DIMMs are reported a little smaller than their nominal size, so
+	// the range can be widened by a tolerance.
+	ok, tolerated := checkRangeTolerance(float64(minSize), float64(maxSize), float64(actualSize),
+		ramDetails.SizeTolerance)
 	log.Info("RAM",
 		"host", host.Name,
 		"profile", profile.Name,
@@ -28,6 +32,8 @@ func checkRAM(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) boo
 		"minSize", minSize,
 		"maxSize", maxSize,
 		"actualSize", actualSize,
+		"tolerance", ramDetails.SizeTolerance,
+		"matchedByTolerance", tolerated,
 		"ok", ok,
 	)
 
@@ -6,6 +6,7 @@ import (
 
 	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
 )
@@ -90,3 +91,106 @@ func TestCheckRAM(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckRAMQuantity(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Rule     *hwcc.Ram
+		Actual   int
+		Expected bool
+	}{
+		{
+			Scenario: "binary-within-min",
+			Rule: &hwcc.Ram{
+				MinimumSize: quantity("192Gi"),
+			},
+			Actual:   192 * 1024,
+			Expected: true,
+		},
+		{
+			Scenario: "binary-under-min",
+			Rule: &hwcc.Ram{
+				MinimumSize: quantity("192Gi"),
+			},
+			Actual:   187 * 1024,
+			Expected: false,
+		},
+		{
+			Scenario: "percent-tolerance-under-min",
+			Rule: &hwcc.Ram{
+				MinimumSize:   quantity("192Gi"),
+				SizeTolerance: "3%",
+			},
+			Actual:   187 * 1024,
+			Expected: true,
+		},
+		{
+			Scenario: "absolute-tolerance-under-min",
+			Rule: &hwcc.Ram{
+				MinimumSize:   quantity("192Gi"),
+				SizeTolerance: "8Gi",
+			},
+			Actual:   187 * 1024,
+			Expected: true,
+		},
+		{
+			Scenario: "tolerance-exceeded",
+			Rule: &hwcc.Ram{
+				MinimumSize:   quantity("192Gi"),
+				SizeTolerance: "1%",
+			},
+			Actual:   187 * 1024,
+			Expected: false,
+		},
+		{
+			Scenario: "decimal-within-min",
+			Rule: &hwcc.Ram{
+				MinimumSize: quantity("200G"),
+			},
+			Actual:   192 * 1024,
+			Expected: true,
+		},
+		{
+			Scenario: "fractional-over-max",
+			Rule: &hwcc.Ram{
+				MaximumSize: quantity("0.5Ti"),
+			},
+			Actual:   768 * 1024,
+			Expected: false,
+		},
+		{
+			Scenario: "quantity-precedence",
+			Rule: &hwcc.Ram{
+				MinimumSizeGB: 256,
+				MinimumSize:   quantity("128Gi"),
+			},
+			Actual:   192 * 1024,
+			Expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Ram: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						RAMMebibytes: tc.Actual,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host),
+				fmt.Sprintf("rule=%v actual=%v", tc.Rule, tc.Actual))
+		})
+	}
+}
+
+func quantity(value string) *resource.Quantity {
+	q := resource.MustParse(value)
+	return &q
+}
@@ -0,0 +1,65 @@
+package classifier
+
+import (
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// ScoreHost returns the fitness score from 0 to 100 of a host matching
+// the characteristics of the profile, and whether the score is at least
+// the threshold. Callers check ProfileMatchesHost first, so that hosts
+// failing the hard constraints are not scored. A profile without
+// scoring gives every host a score of 100.
+func ScoreHost(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) (int, bool) {
+	scoring := profile.Spec.Scoring
+	if scoring == nil {
+		return 100, true
+	}
+
+	score := scoreSoftConstraints(profile, host, scoring.SoftConstraints)
+	ok := score >= int(scoring.Threshold)
+	log.Info("Score",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"score", score,
+		"threshold", scoring.Threshold,
+		"ok", ok,
+	)
+	return score, ok
+}
+
+// scoreSoftConstraints returns the weight of the constraints matching
+// the host as a percentage of the weight of all of them
+func scoreSoftConstraints(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost, constraints []hwcc.SoftConstraint) int {
+	total, matched := 0, 0
+	for i := range constraints {
+		constraint := &constraints[i]
+		weight := int(constraint.Weight)
+		if weight <= 0 {
+			weight = 1
+		}
+		total += weight
+
+		ok := checkNamedBlock(profile, host, constraint.Block)
+		log.Info("SoftConstraint",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"constraintNum", i,
+			"constraintName", constraint.Name,
+			"block", constraint.Block,
+			"weight", weight,
+			"ok", ok,
+		)
+		if ok {
+			matched += weight
+		}
+	}
+	if total == 0 {
+		return 100
+	}
+	// Round to the nearest percent.
+	return (matched*100 + total/2) / total
+}
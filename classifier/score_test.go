@@ -0,0 +1,112 @@
+package classifier
+
+import (
+	"testing"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestScoreHost(t *testing.T) {
+	host := &bmh.BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "host-0",
+			Namespace: "metal3",
+		},
+		Status: bmh.BareMetalHostStatus{
+			HardwareDetails: &bmh.HardwareDetails{
+				CPU: bmh.CPU{
+					Count: 32,
+				},
+				RAMMebibytes: 128 * 1024,
+			},
+		},
+	}
+	smallRAM := hwcc.SoftConstraint{
+		Block:  "small-ram",
+		Weight: 3,
+	}
+	fewCPUs := hwcc.SoftConstraint{
+		Block: "few-cpus",
+	}
+
+	testCases := []struct {
+		Scenario      string
+		Scoring       *hwcc.Scoring
+		ExpectedScore int
+		ExpectedMatch bool
+	}{
+		{
+			Scenario:      "no-scoring",
+			ExpectedScore: 100,
+			ExpectedMatch: true,
+		},
+		{
+			Scenario:      "no-soft-constraints",
+			Scoring:       &hwcc.Scoring{Threshold: 100},
+			ExpectedScore: 100,
+			ExpectedMatch: true,
+		},
+		{
+			Scenario: "all-matched",
+			Scoring: &hwcc.Scoring{
+				SoftConstraints: []hwcc.SoftConstraint{fewCPUs},
+			},
+			ExpectedScore: 100,
+			ExpectedMatch: true,
+		},
+		{
+			Scenario: "weighted",
+			Scoring: &hwcc.Scoring{
+				SoftConstraints: []hwcc.SoftConstraint{smallRAM, fewCPUs},
+				Threshold:       25,
+			},
+			ExpectedScore: 25,
+			ExpectedMatch: true,
+		},
+		{
+			Scenario: "below-threshold",
+			Scoring: &hwcc.Scoring{
+				SoftConstraints: []hwcc.SoftConstraint{smallRAM, fewCPUs},
+				Threshold:       50,
+			},
+			ExpectedScore: 25,
+			ExpectedMatch: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := &hwcc.HardwareClassification{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.Scenario,
+					Namespace: "metal3",
+				},
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						Blocks: []hwcc.NamedCharacteristicsBlock{
+							{
+								Name: "small-ram",
+								CharacteristicsBlock: hwcc.CharacteristicsBlock{
+									Ram: &hwcc.Ram{MaximumSizeGB: 64},
+								},
+							},
+							{
+								Name: "few-cpus",
+								CharacteristicsBlock: hwcc.CharacteristicsBlock{
+									Cpu: &hwcc.Cpu{MaximumCount: 32},
+								},
+							},
+						},
+					},
+					Scoring: tc.Scoring,
+				},
+			}
+			score, ok := ScoreHost(profile, host)
+			assert.Equal(t, tc.ExpectedScore, score)
+			assert.Equal(t, tc.ExpectedMatch, ok)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package classifier
+
+import (
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// HostSelector returns the selector of the hosts considered by the
+// profile, selecting every host if the profile has no host selector
+func HostSelector(profile *hwcc.HardwareClassification) (labels.Selector, error) {
+	if profile.Spec.HostSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(profile.Spec.HostSelector)
+}
+
+// NamespaceSelector returns the selector of the namespaces considered
+// by the cluster profile, selecting every namespace if the profile has
+// no namespace selector
+func NamespaceSelector(profile *hwcc.ClusterHardwareClassification) (labels.Selector, error) {
+	if profile.Spec.NamespaceSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(profile.Spec.NamespaceSelector)
+}
+
+// checkHostSelector checks if the labels of the host match the host
+// selector of the profile
+func checkHostSelector(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) bool {
+	selector, err := HostSelector(profile)
+	if err != nil {
+		log.Error(err, "invalid host selector",
+			"profile", profile.Name,
+			"namespace", profile.Namespace,
+		)
+		return false
+	}
+
+	ok := selector.Matches(labels.Set(host.Labels))
+	log.Info("HostSelector",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"selector", selector.String(),
+		"ok", ok,
+	)
+	return ok
+}
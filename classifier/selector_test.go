@@ -0,0 +1,97 @@
+package classifier
+
+import (
+	"testing"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestCheckHostSelector(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Selector *metav1.LabelSelector
+		Labels   map[string]string
+		Expected bool
+	}{
+		{
+			Scenario: "nil",
+			Selector: nil,
+			Labels:   map[string]string{"rack": "gpu-1"},
+			Expected: true,
+		},
+		{
+			Scenario: "match-labels",
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"rack": "gpu-1"},
+			},
+			Labels:   map[string]string{"rack": "gpu-1"},
+			Expected: true,
+		},
+		{
+			Scenario: "match-labels-unmatched",
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"rack": "gpu-1"},
+			},
+			Labels:   map[string]string{"rack": "r12"},
+			Expected: false,
+		},
+		{
+			Scenario: "no-labels",
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"rack": "gpu-1"},
+			},
+			Labels:   nil,
+			Expected: false,
+		},
+		{
+			Scenario: "match-expressions",
+			Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "rack",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"gpu-1", "gpu-2"},
+					},
+				},
+			},
+			Labels:   map[string]string{"rack": "gpu-2"},
+			Expected: true,
+		},
+		{
+			Scenario: "invalid",
+			Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "rack",
+						Operator: "Like",
+						Values:   []string{"gpu-*"},
+					},
+				},
+			},
+			Labels:   map[string]string{"rack": "gpu-2"},
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HostSelector: tc.Selector,
+				},
+			}
+			host := bmh.BareMetalHost{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: tc.Labels,
+				},
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
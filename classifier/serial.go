@@ -0,0 +1,58 @@
+package classifier
+
+import (
+	"strings"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// ParseSerialNumbers splits the contents of a serial number list stored
+// in a ConfigMap. Serial numbers are separated by whitespace or commas
+// and the text after a # is a comment.
+func ParseSerialNumbers(data string) []string {
+	serials := []string{}
+	for _, line := range strings.Split(data, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		serials = append(serials, strings.FieldsFunc(line, isSerialSeparator)...)
+	}
+	return serials
+}
+
+func isSerialSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t' || r == '\r'
+}
+
+// serialNumberListSet reports whether the list restricts the serial
+// numbers, a list referring to a ConfigMap is set even if it is empty
+func serialNumberListSet(list *hwcc.SerialNumberList) bool {
+	return list != nil && (len(list.Values) > 0 || list.ConfigMapKeyRef != nil)
+}
+
+// serialNumberInList checks if the serial number is in the list,
+// ignoring case and surrounding whitespace
+func serialNumberInList(list *hwcc.SerialNumberList, serial string) bool {
+	if list == nil {
+		return false
+	}
+	serial = strings.TrimSpace(serial)
+	if serial == "" {
+		return false
+	}
+	for _, value := range list.Values {
+		if strings.EqualFold(strings.TrimSpace(value), serial) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSerialNumber checks the serial number against the allowed and
+// the denied serial numbers
+func checkSerialNumber(allowed, denied *hwcc.SerialNumberList, serial string) bool {
+	if serialNumberListSet(allowed) && !serialNumberInList(allowed, serial) {
+		return false
+	}
+	return !serialNumberInList(denied, serial)
+}
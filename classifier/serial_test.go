@@ -0,0 +1,183 @@
+package classifier
+
+import (
+	"testing"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestParseSerialNumbers(t *testing.T) {
+	data := "# batch 2020-11\nCN7016300Q0012\nCN7016300Q0013, CN7016300Q0014\n\n  CN7016300Q0015\t# RMA\n"
+	assert.Equal(t,
+		[]string{"CN7016300Q0012", "CN7016300Q0013", "CN7016300Q0014", "CN7016300Q0015"},
+		ParseSerialNumbers(data))
+	assert.Empty(t, ParseSerialNumbers(""))
+}
+
+func TestCheckSerialNumber(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Allowed  *hwcc.SerialNumberList
+		Denied   *hwcc.SerialNumberList
+		Actual   string
+		Expected bool
+	}{
+		{
+			Scenario: "nil",
+			Actual:   "CN7016300Q0012",
+			Expected: true,
+		},
+		{
+			Scenario: "allowed",
+			Allowed:  &hwcc.SerialNumberList{Values: []string{"CN7016300Q0012", "CN7016300Q0013"}},
+			Actual:   "cn7016300q0012",
+			Expected: true,
+		},
+		{
+			Scenario: "not-allowed",
+			Allowed:  &hwcc.SerialNumberList{Values: []string{"CN7016300Q0012", "CN7016300Q0013"}},
+			Actual:   "CN7016300Q0014",
+			Expected: false,
+		},
+		{
+			Scenario: "denied",
+			Denied:   &hwcc.SerialNumberList{Values: []string{"CN7016300Q0012"}},
+			Actual:   "CN7016300Q0012",
+			Expected: false,
+		},
+		{
+			Scenario: "not-denied",
+			Denied:   &hwcc.SerialNumberList{Values: []string{"CN7016300Q0012"}},
+			Actual:   "CN7016300Q0013",
+			Expected: true,
+		},
+		{
+			Scenario: "allowed-and-denied",
+			Allowed:  &hwcc.SerialNumberList{Values: []string{"CN7016300Q0012"}},
+			Denied:   &hwcc.SerialNumberList{Values: []string{"CN7016300Q0012"}},
+			Actual:   "CN7016300Q0012",
+			Expected: false,
+		},
+		{
+			Scenario: "empty-configmap",
+			Allowed: &hwcc.SerialNumberList{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "serials"},
+					Key:                  "allowed",
+				},
+			},
+			Actual:   "CN7016300Q0012",
+			Expected: false,
+		},
+		{
+			Scenario: "empty-list",
+			Allowed:  &hwcc.SerialNumberList{},
+			Actual:   "CN7016300Q0012",
+			Expected: true,
+		},
+		{
+			Scenario: "missing-serial",
+			Allowed:  &hwcc.SerialNumberList{Values: []string{""}},
+			Actual:   "",
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, checkSerialNumber(tc.Allowed, tc.Denied, tc.Actual))
+		})
+	}
+}
+
+func TestSerialNumberProfiles(t *testing.T) {
+	testCases := []struct {
+		Scenario     string
+		SystemVendor *hwcc.SystemVendor
+		Disk         *hwcc.Disk
+		Expected     bool
+	}{
+		{
+			Scenario: "system-allowed",
+			SystemVendor: &hwcc.SystemVendor{
+				AllowedSerialNumbers: &hwcc.SerialNumberList{Values: []string{"CN7016300Q0012"}},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "system-denied",
+			SystemVendor: &hwcc.SystemVendor{
+				DeniedSerialNumbers: &hwcc.SerialNumberList{Values: []string{"CN7016300Q0012"}},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "disks-allowed",
+			Disk: &hwcc.Disk{
+				AllowedSerialNumbers: &hwcc.SerialNumberList{Values: []string{"BTYF1234", "S4EVNX0N"}},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "disk-not-allowed",
+			Disk: &hwcc.Disk{
+				AllowedSerialNumbers: &hwcc.SerialNumberList{Values: []string{"BTYF1234"}},
+			},
+			Expected: false,
+		},
+		{
+			Scenario: "disk-of-type-allowed",
+			Disk: &hwcc.Disk{
+				Type:                 hwcc.DiskTypeNVME,
+				AllowedSerialNumbers: &hwcc.SerialNumberList{Values: []string{"S4EVNX0N"}},
+			},
+			Expected: true,
+		},
+		{
+			Scenario: "disk-denied",
+			Disk: &hwcc.Disk{
+				Type:                hwcc.DiskTypeNVME,
+				DeniedSerialNumbers: &hwcc.SerialNumberList{Values: []string{"BTYF1234"}},
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						SystemVendor: tc.SystemVendor,
+						Disk:         tc.Disk,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						SystemVendor: bmh.HardwareSystemVendor{
+							Manufacturer: "Dell Inc.",
+							ProductName:  "PowerEdge R640",
+							SerialNumber: "CN7016300Q0012",
+						},
+						Storage: []bmh.Storage{
+							{
+								Name:         "/dev/sda",
+								Rotational:   false,
+								SerialNumber: "BTYF1234",
+							},
+							{
+								Name:         "/dev/nvme0n1",
+								SerialNumber: "S4EVNX0N",
+							},
+						},
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
@@ -7,20 +7,26 @@ import (
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
 )
 
-// checkFirmware it filters the bmh host as per the hardware details provided by user
+// checkSystemVendor it filters the bmh host as per the hardware details provided by user
 func checkSystemVendor(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) bool {
 	systemVendorDetails := profile.Spec.HardwareCharacteristics.SystemVendor
 	if systemVendorDetails == nil {
 		return true
 	}
 
-	ok := checkString(systemVendorDetails.Manufacturer, host.Status.HardwareDetails.SystemVendor.Manufacturer)
+	ok := checkVendor(systemVendorDetails.Manufacturer,
+		systemVendorDetails.ManufacturerMatchType,
+		systemVendorDetails.ExcludedManufacturers,
+		host.Status.HardwareDetails.SystemVendor.Manufacturer)
 	log.Info("System Vendor",
 		"host", host.Name,
 		"profile", profile.Name,
 		"namespace", host.Namespace,
 		"Manufacturer", systemVendorDetails.Manufacturer,
+		"Manufacturer match type", systemVendorDetails.ManufacturerMatchType,
+		"excluded Manufacturers", systemVendorDetails.ExcludedManufacturers,
 		"actual Manufacturer", host.Status.HardwareDetails.SystemVendor.Manufacturer,
+		"canonical Manufacturer", canonicalVendor(host.Status.HardwareDetails.SystemVendor.Manufacturer),
 		"ok", ok,
 	)
 
@@ -28,12 +34,19 @@ func checkSystemVendor(profile *hwcc.HardwareClassification, host *bmh.BareMetal
 		return false
 	}
 
-	ok = checkSubString(systemVendorDetails.ProductName, host.Status.HardwareDetails.SystemVendor.ProductName)
+	ok = checkMatchType(systemVendorDetails.ProductName,
+		systemVendorDetails.ProductNameMatchType,
+		hwcc.MatchTypeContains,
+		host.Status.HardwareDetails.SystemVendor.ProductName) &&
+		!checkAnyStringMatcher(systemVendorDetails.ExcludedProductNames,
+			host.Status.HardwareDetails.SystemVendor.ProductName)
 	log.Info("System Vendor",
 		"host", host.Name,
 		"profile", profile.Name,
 		"namespace", host.Namespace,
 		"ProductName", systemVendorDetails.ProductName,
+		"ProductName match type", systemVendorDetails.ProductNameMatchType,
+		"excluded ProductNames", systemVendorDetails.ExcludedProductNames,
 		"actual ProductName", host.Status.HardwareDetails.SystemVendor.ProductName,
 		"ok", ok,
 	)
@@ -41,6 +54,20 @@ func checkSystemVendor(profile *hwcc.HardwareClassification, host *bmh.BareMetal
 		return false
 	}
 
+	ok = checkSerialNumber(systemVendorDetails.AllowedSerialNumbers,
+		systemVendorDetails.DeniedSerialNumbers,
+		host.Status.HardwareDetails.SystemVendor.SerialNumber)
+	log.Info("System Vendor",
+		"host", host.Name,
+		"profile", profile.Name,
+		"namespace", host.Namespace,
+		"actual SerialNumber", host.Status.HardwareDetails.SystemVendor.SerialNumber,
+		"ok", ok,
+	)
+	if !ok {
+		return false
+	}
+
 	return true
 }
 
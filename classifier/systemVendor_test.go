@@ -157,3 +157,105 @@ func TestCheckSystemVendorProductName(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemVendorMatchTypes(t *testing.T) {
+	testCases := []struct {
+		Scenario           string
+		Rule               *hwcc.SystemVendor
+		ActualManufacturer string
+		ActualProductName  string
+		Expected           bool
+	}{
+		{
+			Scenario: "glob-matched",
+			Rule: &hwcc.SystemVendor{
+				ProductName:          "PowerEdge R6*",
+				ProductNameMatchType: hwcc.MatchTypeGlob,
+			},
+			ActualManufacturer: "Dell Inc.",
+			ActualProductName:  "PowerEdge R640",
+			Expected:           true,
+		},
+		{
+			Scenario: "glob-excluded",
+			Rule: &hwcc.SystemVendor{
+				ProductName:          "PowerEdge R6*",
+				ProductNameMatchType: hwcc.MatchTypeGlob,
+				ExcludedProductNames: []hwcc.StringMatcher{
+					{Value: "PowerEdge R610"},
+				},
+			},
+			ActualManufacturer: "Dell Inc.",
+			ActualProductName:  "PowerEdge R610",
+			Expected:           false,
+		},
+		{
+			Scenario: "manufacturer-prefix-matched",
+			Rule: &hwcc.SystemVendor{
+				Manufacturer:          "Dell",
+				ManufacturerMatchType: hwcc.MatchTypePrefix,
+			},
+			ActualManufacturer: "Dell Inc.",
+			ActualProductName:  "PowerEdge R640",
+			Expected:           true,
+		},
+		{
+			Scenario: "manufacturer-regex-unmatched",
+			Rule: &hwcc.SystemVendor{
+				Manufacturer:          "^(HPE|Lenovo)$",
+				ManufacturerMatchType: hwcc.MatchTypeRegex,
+			},
+			ActualManufacturer: "Dell Inc.",
+			ActualProductName:  "PowerEdge R640",
+			Expected:           false,
+		},
+		{
+			Scenario: "manufacturer-excluded",
+			Rule: &hwcc.SystemVendor{
+				ExcludedManufacturers: []hwcc.StringMatcher{
+					{Value: "QEMU"},
+					{Value: "Dell", MatchType: hwcc.MatchTypePrefix},
+				},
+			},
+			ActualManufacturer: "Dell Inc.",
+			ActualProductName:  "PowerEdge R640",
+			Expected:           false,
+		},
+		{
+			Scenario: "invalid-exclusion",
+			Rule: &hwcc.SystemVendor{
+				ExcludedProductNames: []hwcc.StringMatcher{
+					{Value: "R6[", MatchType: hwcc.MatchTypeGlob},
+				},
+			},
+			ActualManufacturer: "Dell Inc.",
+			ActualProductName:  "PowerEdge R640",
+			Expected:           false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: hwcc.HardwareCharacteristics{
+						SystemVendor: tc.Rule,
+					},
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						SystemVendor: bmh.HardwareSystemVendor{
+							Manufacturer: tc.ActualManufacturer,
+							ProductName:  tc.ActualProductName,
+						},
+					},
+				},
+			}
+			// Invalid profiles are rejected before they are matched,
+			// as done by the controller.
+			matched := ValidateProfile(&profile) == nil && ProfileMatchesHost(&profile, &host)
+			assert.Equal(t, tc.Expected, matched)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package classifier
+
+import (
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// HostTier returns the name of the highest of the ordered tiers of the
+// profile met by the host, or an empty string if it meets none of
+// them. It does not check the characteristics of the profile itself.
+func HostTier(profile *hwcc.HardwareClassification, host *bmh.BareMetalHost) string {
+	for i := range profile.Spec.Tiers {
+		tier := &profile.Spec.Tiers[i]
+		ok := checkNamedBlock(profile, host, tier.Block)
+		log.Info("Tier",
+			"host", host.Name,
+			"profile", profile.Name,
+			"namespace", host.Namespace,
+			"tierNum", i,
+			"tierName", tier.Name,
+			"block", tier.Block,
+			"ok", ok,
+		)
+		if ok {
+			return tier.Name
+		}
+	}
+	return ""
+}
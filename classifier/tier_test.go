@@ -0,0 +1,105 @@
+package classifier
+
+import (
+	"testing"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestHostTier(t *testing.T) {
+	profile := &hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "compute",
+			Namespace: "metal3",
+		},
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{
+						Name: "large",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Cpu: &hwcc.Cpu{MinimumCount: 64},
+							Ram: &hwcc.Ram{MinimumSizeGB: 512},
+						},
+					},
+					{
+						Name: "medium",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Cpu: &hwcc.Cpu{MinimumCount: 32},
+						},
+					},
+				},
+			},
+			Tiers: []hwcc.Tier{
+				{
+					Name:  "gold",
+					Block: "large",
+				},
+				{
+					Name:  "silver",
+					Block: "medium",
+				},
+				{
+					Name: "bronze",
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+		CPUCount int
+		RAMGiB   int
+		Expected string
+	}{
+		{
+			Scenario: "gold",
+			CPUCount: 64,
+			RAMGiB:   512,
+			Expected: "gold",
+		},
+		{
+			Scenario: "silver",
+			CPUCount: 64,
+			RAMGiB:   256,
+			Expected: "silver",
+		},
+		{
+			Scenario: "bronze",
+			CPUCount: 16,
+			RAMGiB:   64,
+			Expected: "bronze",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := &bmh.BareMetalHost{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "host-0",
+					Namespace: "metal3",
+				},
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						CPU: bmh.CPU{
+							Count: tc.CPUCount,
+						},
+						RAMMebibytes: tc.RAMGiB * 1024,
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, HostTier(profile, host))
+		})
+	}
+
+	profile.Spec.Tiers = profile.Spec.Tiers[:1]
+	host := &bmh.BareMetalHost{
+		Status: bmh.BareMetalHostStatus{
+			HardwareDetails: &bmh.HardwareDetails{},
+		},
+	}
+	assert.Equal(t, "", HostTier(profile, host))
+}
@@ -0,0 +1,72 @@
+package classifier
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// parseTolerance returns the percentage of the bounds or the absolute
+// amount given by the tolerance, only one of which is set
+func parseTolerance(tolerance hwcc.Tolerance) (percent, absolute float64, err error) {
+	value := string(tolerance)
+	if strings.HasSuffix(value, "%") {
+		percent, err = strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || percent < 0 {
+			return 0, 0, fmt.Errorf("invalid percentage %q", value)
+		}
+		return percent, 0, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil || quantity.Sign() < 0 {
+		return 0, 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return 0, float64(quantity.MilliValue()) / 1000, nil
+}
+
+// checkRangeTolerance checks the value against the range widened by the
+// tolerance. It also reports whether the value is only in the range
+// thanks to the tolerance. Bounds of 0 are not checked.
+func checkRangeTolerance(min, max, value float64, tolerance hwcc.Tolerance) (ok, tolerated bool) {
+	if checkRangeFloat(min, max, value) {
+		return true, false
+	}
+	if tolerance == "" {
+		return false, false
+	}
+
+	percent, absolute, err := parseTolerance(tolerance)
+	if err != nil {
+		log.Error(err, "invalid tolerance", "tolerance", tolerance)
+		return false, false
+	}
+	widen := func(bound float64) float64 {
+		if percent > 0 {
+			return bound * percent / 100
+		}
+		return absolute
+	}
+	if min > 0 {
+		min = math.Max(min-widen(min), 0)
+	}
+	if max > 0 {
+		max += widen(max)
+	}
+	ok = checkRangeFloat(min, max, value)
+	return ok, ok
+}
+
+func checkRangeFloat(min, max, value float64) bool {
+	if min > 0 && value < min {
+		return false
+	}
+	if max > 0 && value > max {
+		return false
+	}
+	return true
+}
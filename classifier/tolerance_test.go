@@ -0,0 +1,87 @@
+package classifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestParseTolerance(t *testing.T) {
+	percent, absolute, err := parseTolerance("2.5%")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, percent)
+	assert.Equal(t, 0.0, absolute)
+
+	percent, absolute, err = parseTolerance("8Gi")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, percent)
+	assert.Equal(t, float64(8<<30), absolute)
+
+	_, _, err = parseTolerance("five%")
+	assert.Error(t, err)
+	_, _, err = parseTolerance("8 GiB")
+	assert.Error(t, err)
+}
+
+func TestCheckRangeTolerance(t *testing.T) {
+	testCases := []struct {
+		Scenario          string
+		Min, Max, Value   float64
+		Tolerance         hwcc.Tolerance
+		ExpectedOK        bool
+		ExpectedTolerated bool
+	}{
+		{
+			Scenario:   "within-range",
+			Min:        100,
+			Max:        200,
+			Value:      150,
+			Tolerance:  "10%",
+			ExpectedOK: true,
+		},
+		{
+			Scenario:          "under-min",
+			Min:               100,
+			Value:             95,
+			Tolerance:         "10%",
+			ExpectedOK:        true,
+			ExpectedTolerated: true,
+		},
+		{
+			Scenario:          "over-max",
+			Max:               200,
+			Value:             215,
+			Tolerance:         "20",
+			ExpectedOK:        true,
+			ExpectedTolerated: true,
+		},
+		{
+			Scenario:  "beyond-tolerance",
+			Min:       100,
+			Value:     85,
+			Tolerance: "10%",
+		},
+		{
+			Scenario: "no-tolerance",
+			Min:      100,
+			Value:    95,
+		},
+		{
+			Scenario:          "tolerance-over-min",
+			Min:               10,
+			Value:             1,
+			Tolerance:         "20",
+			ExpectedOK:        true,
+			ExpectedTolerated: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			ok, tolerated := checkRangeTolerance(tc.Min, tc.Max, tc.Value, tc.Tolerance)
+			assert.Equal(t, tc.ExpectedOK, ok)
+			assert.Equal(t, tc.ExpectedTolerated, tolerated)
+		})
+	}
+}
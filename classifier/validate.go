@@ -0,0 +1,258 @@
+package classifier
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// ValidateProfile checks the parts of the profile which cannot be
+// validated by the CRD schema, such as patterns and version
+// constraints. A profile failing validation must not be matched against
+// hosts.
+func ValidateProfile(profile *hwcc.HardwareClassification) error {
+	return validateProfile(profile).ToAggregate()
+}
+
+func validateProfile(profile *hwcc.HardwareClassification) field.ErrorList {
+	fldPath := field.NewPath("spec", "hardwareCharacteristics")
+	characteristics := &profile.Spec.HardwareCharacteristics
+	allErrs := validateCharacteristics(characteristics, fldPath)
+	if expression := profile.Spec.HardwareCharacteristics.Expression; expression != "" {
+		if _, err := compileCELExpression(expression); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("expression"), expression, err.Error()))
+		}
+	}
+	if _, err := HostSelector(profile); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "hostSelector"), profile.Spec.HostSelector, err.Error()))
+	}
+	if scoring := profile.Spec.Scoring; scoring != nil {
+		constraintsPath := field.NewPath("spec", "scoring", "softConstraints")
+		for i := range scoring.SoftConstraints {
+			allErrs = append(allErrs, validateBlockReference(characteristics,
+				scoring.SoftConstraints[i].Block, constraintsPath.Index(i).Child("block"))...)
+		}
+	}
+	tiersPath := field.NewPath("spec", "tiers")
+	tierNames := map[string]bool{}
+	for i := range profile.Spec.Tiers {
+		tier := &profile.Spec.Tiers[i]
+		namePath := tiersPath.Index(i).Child("name")
+		if tierNames[tier.Name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, tier.Name))
+		}
+		tierNames[tier.Name] = true
+		for _, msg := range validation.IsValidLabelValue(tier.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, tier.Name, msg))
+		}
+		allErrs = append(allErrs, validateBlockReference(characteristics,
+			tier.Block, tiersPath.Index(i).Child("block"))...)
+	}
+	for i, name := range profile.Spec.RequiredProfiles {
+		if name == profile.Name {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "requiredProfiles").Index(i),
+				name, "a profile cannot require itself"))
+		}
+	}
+	if classLabel := profile.Spec.ClassLabel; classLabel != "" {
+		classLabelPath := field.NewPath("spec", "classLabel")
+		if profile.Spec.ExclusiveGroup == "" {
+			allErrs = append(allErrs, field.Invalid(classLabelPath, classLabel, "requires an exclusiveGroup"))
+		}
+		for _, msg := range validation.IsQualifiedName(classLabel) {
+			allErrs = append(allErrs, field.Invalid(classLabelPath, classLabel, msg))
+		}
+		// The name of the profile is the value of the class label.
+		for _, msg := range validation.IsValidLabelValue(profile.Name) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), profile.Name,
+				"is the value of the classLabel: "+msg))
+		}
+	}
+	return allErrs
+}
+
+// ValidateClusterProfile checks the parts of the cluster profile which
+// cannot be validated by the CRD schema
+func ValidateClusterProfile(profile *hwcc.ClusterHardwareClassification) error {
+	allErrs := validateProfile(profile.Profile(""))
+	if _, err := NamespaceSelector(profile); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "namespaceSelector"), profile.Spec.NamespaceSelector, err.Error()))
+	}
+	return allErrs.ToAggregate()
+}
+
+func validateCharacteristics(characteristics *hwcc.HardwareCharacteristics, fldPath *field.Path) field.ErrorList {
+	expr := characteristicsExpression(characteristics)
+	allErrs := validateCharacteristicsBlock(&expr.block, fldPath)
+
+	blocksPath := fldPath.Child("blocks")
+	blockNames := map[string]bool{}
+	for i := range characteristics.Blocks {
+		block := &characteristics.Blocks[i]
+		if blockNames[block.Name] {
+			allErrs = append(allErrs, field.Duplicate(blocksPath.Index(i).Child("name"), block.Name))
+		}
+		blockNames[block.Name] = true
+		allErrs = append(allErrs, validateCharacteristicsBlock(&block.CharacteristicsBlock, blocksPath.Index(i))...)
+	}
+
+	for i := range characteristics.AllOf {
+		allErrs = append(allErrs, validateCharacteristicsExpression(characteristics,
+			&characteristics.AllOf[i], fldPath.Child("allOf").Index(i))...)
+	}
+	for i := range characteristics.AnyOf {
+		allErrs = append(allErrs, validateCharacteristicsExpression(characteristics,
+			&characteristics.AnyOf[i], fldPath.Child("anyOf").Index(i))...)
+	}
+	if characteristics.Not != nil {
+		allErrs = append(allErrs, validateCharacteristicsExpression(characteristics,
+			characteristics.Not, fldPath.Child("not"))...)
+	}
+	return allErrs
+}
+
+func validateCharacteristicsExpression(characteristics *hwcc.HardwareCharacteristics, expr *hwcc.CharacteristicsExpression, fldPath *field.Path) field.ErrorList {
+	allErrs := validateBlockReference(characteristics, expr.Block, fldPath.Child("block"))
+	for i, name := range expr.AnyOf {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("anyOf").Index(i), "must name a block"))
+			continue
+		}
+		allErrs = append(allErrs, validateBlockReference(characteristics, name, fldPath.Child("anyOf").Index(i))...)
+	}
+	return allErrs
+}
+
+// validateBlockReference checks that the named block is defined, an
+// empty name refers to no block
+func validateBlockReference(characteristics *hwcc.HardwareCharacteristics, name string, fldPath *field.Path) field.ErrorList {
+	if namedBlock(characteristics, name) == nil {
+		return field.ErrorList{field.NotFound(fldPath, name)}
+	}
+	return nil
+}
+
+func validateCharacteristicsBlock(characteristics *hwcc.CharacteristicsBlock, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cpu := characteristics.Cpu; cpu != nil {
+		cpuPath := fldPath.Child("cpu")
+		allErrs = append(allErrs, validateStringMatcher(cpu.Model, cpuPath.Child("model"))...)
+		allErrs = append(allErrs, validateStringMatchers(cpu.ExcludedModels, cpuPath.Child("excludedModels"))...)
+		if cpu.Architecture != "" && len(cpu.Architectures) > 0 {
+			allErrs = append(allErrs, field.Invalid(cpuPath.Child("architectures"), cpu.Architectures,
+				"cannot be set together with architecture"))
+		}
+		allErrs = append(allErrs, validateTolerance(cpu.SpeedTolerance, cpuPath.Child("speedTolerance"))...)
+	}
+
+	if ram := characteristics.Ram; ram != nil {
+		allErrs = append(allErrs, validateTolerance(ram.SizeTolerance, fldPath.Child("ram", "sizeTolerance"))...)
+	}
+
+	if disk := characteristics.Disk; disk != nil {
+		diskPath := fldPath.Child("disk")
+		allErrs = append(allErrs, validateStringMatcher(disk.Vendor, diskPath.Child("vendor"))...)
+		allErrs = append(allErrs, validateStringMatcher(disk.Model, diskPath.Child("model"))...)
+		allErrs = append(allErrs, validateStringMatchers(disk.DeniedModels, diskPath.Child("deniedModels"))...)
+		for i := range disk.Groups {
+			groupPath := diskPath.Child("groups").Index(i)
+			allErrs = append(allErrs, validateStringMatcher(disk.Groups[i].Vendor, groupPath.Child("vendor"))...)
+			allErrs = append(allErrs, validateStringMatcher(disk.Groups[i].Model, groupPath.Child("model"))...)
+		}
+	}
+
+	if systemVendor := characteristics.SystemVendor; systemVendor != nil {
+		systemVendorPath := fldPath.Child("systemVendor")
+		allErrs = append(allErrs, validatePattern(systemVendor.Manufacturer, systemVendor.ManufacturerMatchType,
+			systemVendorPath.Child("manufacturer"))...)
+		allErrs = append(allErrs, validateStringMatchers(systemVendor.ExcludedManufacturers,
+			systemVendorPath.Child("excludedManufacturers"))...)
+		allErrs = append(allErrs, validatePattern(systemVendor.ProductName, systemVendor.ProductNameMatchType,
+			systemVendorPath.Child("productName"))...)
+		allErrs = append(allErrs, validateStringMatchers(systemVendor.ExcludedProductNames,
+			systemVendorPath.Child("excludedProductNames"))...)
+	}
+
+	if firmware := characteristics.Firmware; firmware != nil {
+		biosPath := fldPath.Child("firmware", "bios")
+		bios := &firmware.BIOS
+		allErrs = append(allErrs, validatePattern(bios.Vendor, bios.VendorMatchType, biosPath.Child("vendor"))...)
+		allErrs = append(allErrs, validateStringMatchers(bios.ExcludedVendors, biosPath.Child("excludedVendors"))...)
+		allErrs = append(allErrs, validateVersion(bios.MinorVersion, biosPath.Child("minorVersion"))...)
+		allErrs = append(allErrs, validateVersion(bios.MajorVersion, biosPath.Child("majorVersion"))...)
+		if bios.VersionConstraint != "" {
+			if _, err := parseVersionConstraints(bios.VersionConstraint); err != nil {
+				allErrs = append(allErrs, field.Invalid(biosPath.Child("versionConstraint"), bios.VersionConstraint, err.Error()))
+			}
+		}
+		allErrs = append(allErrs, validateDate(bios.MinimumReleaseDate, biosPath.Child("minimumReleaseDate"))...)
+		allErrs = append(allErrs, validateDate(bios.MaximumReleaseDate, biosPath.Child("maximumReleaseDate"))...)
+	}
+
+	if hostname := characteristics.Hostname; hostname != nil {
+		hostnamePath := fldPath.Child("hostname")
+		matchType := hostname.MatchType
+		if matchType == "" {
+			matchType = hwcc.MatchTypeGlob
+		}
+		allErrs = append(allErrs, validatePattern(hostname.Name, matchType, hostnamePath.Child("name"))...)
+		allErrs = append(allErrs, validateStringMatchers(hostname.ExcludedNames, hostnamePath.Child("excludedNames"))...)
+	}
+
+	return allErrs
+}
+
+func validateStringMatchers(matchers []hwcc.StringMatcher, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i := range matchers {
+		allErrs = append(allErrs, validateStringMatcher(&matchers[i], fldPath.Index(i))...)
+	}
+	return allErrs
+}
+
+func validateStringMatcher(matcher *hwcc.StringMatcher, fldPath *field.Path) field.ErrorList {
+	if matcher == nil {
+		return nil
+	}
+	return validatePattern(matcher.Value, matcher.MatchType, fldPath.Child("value"))
+}
+
+func validatePattern(value string, matchType hwcc.MatchType, fldPath *field.Path) field.ErrorList {
+	if _, err := matchString(value, matchType, ""); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value,
+			"invalid "+string(matchType)+" pattern: "+err.Error())}
+	}
+	return nil
+}
+
+func validateVersion(value string, fldPath *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if _, err := parseVersion(value); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	return nil
+}
+
+func validateTolerance(value hwcc.Tolerance, fldPath *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if _, _, err := parseTolerance(value); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	return nil
+}
+
+func validateDate(value string, fldPath *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if _, err := parseBIOSDate(value); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	return nil
+}
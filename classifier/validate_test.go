@@ -0,0 +1,294 @@
+package classifier
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestValidateProfile(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Rule     hwcc.HardwareCharacteristics
+		Errors   []string
+	}{
+		{
+			Scenario: "empty",
+			Rule:     hwcc.HardwareCharacteristics{},
+		},
+		{
+			Scenario: "valid",
+			Rule: hwcc.HardwareCharacteristics{
+				SystemVendor: &hwcc.SystemVendor{
+					ProductName:          "PowerEdge R6*",
+					ProductNameMatchType: hwcc.MatchTypeGlob,
+				},
+				Firmware: &hwcc.Firmware{
+					BIOS: hwcc.BIOS{
+						Vendor:             "^Dell",
+						VendorMatchType:    hwcc.MatchTypeRegex,
+						VersionConstraint:  ">=2.10.0, <3.0",
+						MinimumReleaseDate: "2021-03-01",
+					},
+				},
+			},
+		},
+		{
+			Scenario: "invalid-patterns",
+			Rule: hwcc.HardwareCharacteristics{
+				Cpu: &hwcc.Cpu{
+					Model: &hwcc.StringMatcher{Value: "Gold (62", MatchType: hwcc.MatchTypeRegex},
+				},
+				Disk: &hwcc.Disk{
+					Groups: []hwcc.DiskGroup{
+						{},
+						{Model: &hwcc.StringMatcher{Value: "MZ7[", MatchType: hwcc.MatchTypeGlob}},
+					},
+				},
+				SystemVendor: &hwcc.SystemVendor{
+					ExcludedProductNames: []hwcc.StringMatcher{
+						{Value: "R6[", MatchType: hwcc.MatchTypeGlob},
+					},
+				},
+				Hostname: &hwcc.Hostname{
+					Name: "r12-stor-[",
+				},
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{Name: "any"},
+					{
+						Name: "gold",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Cpu: &hwcc.Cpu{Model: &hwcc.StringMatcher{Value: "Gold (62", MatchType: hwcc.MatchTypeRegex}},
+						},
+					},
+				},
+				AllOf: []hwcc.CharacteristicsExpression{
+					{AnyOf: []string{"any", "gold"}},
+				},
+				Ram: &hwcc.Ram{
+					SizeTolerance: "-3%",
+				},
+				Expression: "hardware.cpu.count >=",
+			},
+			Errors: []string{
+				"spec.hardwareCharacteristics.ram.sizeTolerance",
+				"spec.hardwareCharacteristics.cpu.model.value",
+				"spec.hardwareCharacteristics.disk.groups[1].model.value",
+				"spec.hardwareCharacteristics.systemVendor.excludedProductNames[0].value",
+				"spec.hardwareCharacteristics.hostname.name",
+				"spec.hardwareCharacteristics.blocks[1].cpu.model.value",
+				"spec.hardwareCharacteristics.expression",
+			},
+		},
+		{
+			Scenario: "architecture-and-architectures",
+			Rule: hwcc.HardwareCharacteristics{
+				Cpu: &hwcc.Cpu{
+					Architecture:  "x86_64",
+					Architectures: []hwcc.CPUArchitecture{"aarch64"},
+				},
+			},
+			Errors: []string{
+				"spec.hardwareCharacteristics.cpu.architectures",
+			},
+		},
+		{
+			Scenario: "invalid-firmware",
+			Rule: hwcc.HardwareCharacteristics{
+				Firmware: &hwcc.Firmware{
+					BIOS: hwcc.BIOS{
+						MinorVersion:       "latest",
+						VersionConstraint:  ">=2.10.0,",
+						MaximumReleaseDate: "tomorrow",
+					},
+				},
+			},
+			Errors: []string{
+				"spec.hardwareCharacteristics.firmware.bios.minorVersion",
+				"spec.hardwareCharacteristics.firmware.bios.versionConstraint",
+				"spec.hardwareCharacteristics.firmware.bios.maximumReleaseDate",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: tc.Rule,
+				},
+			}
+			err := ValidateProfile(&profile)
+			if len(tc.Errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				for _, expected := range tc.Errors {
+					assert.Contains(t, err.Error(), expected)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateClassLabel(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Name     string
+		Spec     hwcc.HardwareClassificationSpec
+		Valid    bool
+	}{
+		{
+			Scenario: "valid",
+			Spec: hwcc.HardwareClassificationSpec{
+				ExclusiveGroup: "class",
+				ClassLabel:     "hardwareclassification.metal3.io/class",
+			},
+			Valid: true,
+		},
+		{
+			Scenario: "no-group",
+			Spec: hwcc.HardwareClassificationSpec{
+				ClassLabel: "hardwareclassification.metal3.io/class",
+			},
+			Valid: false,
+		},
+		{
+			Scenario: "invalid-key",
+			Spec: hwcc.HardwareClassificationSpec{
+				ExclusiveGroup: "class",
+				ClassLabel:     "hardware class",
+			},
+			Valid: false,
+		},
+		{
+			Scenario: "name-too-long-for-label-value",
+			Name:     "compute-" + strings.Repeat("x", 60),
+			Spec: hwcc.HardwareClassificationSpec{
+				ExclusiveGroup: "class",
+				ClassLabel:     "hardwareclassification.metal3.io/class",
+			},
+			Valid: false,
+		},
+		{
+			Scenario: "long-name-without-class-label",
+			Name:     "compute-" + strings.Repeat("x", 60),
+			Spec: hwcc.HardwareClassificationSpec{
+				ExclusiveGroup: "class",
+			},
+			Valid: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{Spec: tc.Spec}
+			profile.Name = tc.Name
+			err := ValidateProfile(&profile)
+			if tc.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateScoring(t *testing.T) {
+	profile := hwcc.HardwareClassification{
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{Name: "storage"},
+				},
+			},
+			Scoring: &hwcc.Scoring{
+				SoftConstraints: []hwcc.SoftConstraint{
+					{},
+					{Block: "storage"},
+					{Block: "compute"},
+				},
+			},
+		},
+	}
+	err := ValidateProfile(&profile)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.scoring.softConstraints[2].block: Not found")
+		assert.NotContains(t, err.Error(), "spec.scoring.softConstraints[0]")
+		assert.NotContains(t, err.Error(), "spec.scoring.softConstraints[1]")
+	}
+}
+
+func TestValidateTiers(t *testing.T) {
+	profile := hwcc.HardwareClassification{
+		Spec: hwcc.HardwareClassificationSpec{
+			Tiers: []hwcc.Tier{
+				{Name: "gold"},
+				{Name: "gold"},
+				{Name: "silver tier"},
+				{
+					Name:  "bronze",
+					Block: "small",
+				},
+			},
+		},
+	}
+	err := ValidateProfile(&profile)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.tiers[1].name: Duplicate value")
+		assert.Contains(t, err.Error(), "spec.tiers[2].name")
+		assert.Contains(t, err.Error(), "spec.tiers[3].block: Not found")
+		assert.NotContains(t, err.Error(), "spec.tiers[0]")
+	}
+}
+
+func TestValidateRequiredProfiles(t *testing.T) {
+	profile := hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-fast"},
+		Spec: hwcc.HardwareClassificationSpec{
+			RequiredProfiles: []string{"baseline-approved", "storage-fast"},
+		},
+	}
+	err := ValidateProfile(&profile)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.requiredProfiles[1]")
+		assert.NotContains(t, err.Error(), "spec.requiredProfiles[0]")
+	}
+}
+
+func TestValidateBlocks(t *testing.T) {
+	profile := hwcc.HardwareClassification{
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{Name: "dell"},
+					{Name: "dell"},
+					{
+						Name: "storage",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Hostname: &hwcc.Hostname{Name: "r12-stor-["},
+						},
+					},
+				},
+				AllOf: []hwcc.CharacteristicsExpression{
+					{Block: "dell", AnyOf: []string{"hpe", ""}},
+				},
+				Not: &hwcc.CharacteristicsExpression{
+					Block: "lenovo",
+				},
+			},
+		},
+	}
+	err := ValidateProfile(&profile)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.hardwareCharacteristics.blocks[1].name: Duplicate value")
+		assert.Contains(t, err.Error(), "spec.hardwareCharacteristics.blocks[2].hostname.name")
+		assert.Contains(t, err.Error(), "spec.hardwareCharacteristics.allOf[0].anyOf[0]: Not found")
+		assert.Contains(t, err.Error(), "spec.hardwareCharacteristics.allOf[0].anyOf[1]: Required value")
+		assert.Contains(t, err.Error(), "spec.hardwareCharacteristics.not.block: Not found")
+		assert.NotContains(t, err.Error(), "spec.hardwareCharacteristics.allOf[0].block")
+	}
+}
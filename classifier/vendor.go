@@ -0,0 +1,96 @@
+package classifier
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// vendorAliases maps normalized raw vendor names, as reported by the
+// host firmware, to canonical vendor names
+var vendorAliases = map[string]string{}
+
+// SetVendorAliases replaces the table mapping the raw manufacturer and
+// bios vendor names reported for hosts to canonical names, e.g. "DELL"
+// and "Dell Inc." to "Dell". Raw names are compared ignoring case and
+// extra whitespace. It is not safe to call while hosts are being
+// classified.
+func SetVendorAliases(aliases map[string]string) {
+	vendorAliases = make(map[string]string, len(aliases))
+	for raw, canonical := range aliases {
+		vendorAliases[normalizeVendor(raw)] = canonical
+	}
+}
+
+// LoadVendorAliases reads a vendor alias table from a YAML or JSON
+// file mapping raw vendor names to canonical names
+func LoadVendorAliases(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read vendor aliases")
+	}
+	aliases := map[string]string{}
+	if err := yaml.Unmarshal(data, &aliases); err != nil {
+		return nil, errors.Wrapf(err, "could not parse vendor aliases in %s", path)
+	}
+	return aliases, nil
+}
+
+// normalizeVendor folds the case and the whitespace of a vendor name
+func normalizeVendor(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// canonicalVendor returns the canonical name of the vendor, or the
+// vendor name without extra whitespace if it has no alias
+func canonicalVendor(name string) string {
+	if canonical, ok := vendorAliases[normalizeVendor(name)]; ok {
+		return canonical
+	}
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// checkVendorMatcher checks if the vendor name reported for the host
+// matches the expected vendor after both are reduced to their
+// canonical names. Exact, prefix and contains matches ignore case.
+// Patterns are tried against both the canonical and the raw name.
+func checkVendorMatcher(matcher *hwcc.StringMatcher, hostSpecific string) bool {
+	if matcher == nil {
+		return true
+	}
+
+	canonical := canonicalVendor(hostSpecific)
+	switch matcher.MatchType {
+	case hwcc.MatchTypeRegex, hwcc.MatchTypeGlob:
+		return checkStringMatcher(matcher, canonical) || checkStringMatcher(matcher, hostSpecific)
+	case hwcc.MatchTypePrefix, hwcc.MatchTypeContains:
+		folded := &hwcc.StringMatcher{
+			Value:     strings.ToLower(matcher.Value),
+			MatchType: matcher.MatchType,
+		}
+		return checkStringMatcher(folded, strings.ToLower(canonical)) ||
+			checkStringMatcher(folded, strings.ToLower(hostSpecific))
+	default:
+		return strings.EqualFold(canonicalVendor(matcher.Value), canonical)
+	}
+}
+
+// checkVendor checks the vendor name reported for the host against
+// the expected vendor and the excluded vendors
+func checkVendor(expected string, matchType hwcc.MatchType, excluded []hwcc.StringMatcher, hostSpecific string) bool {
+	if expected != "" {
+		if !checkVendorMatcher(&hwcc.StringMatcher{Value: expected, MatchType: matchType}, hostSpecific) {
+			return false
+		}
+	}
+	for i := range excluded {
+		if checkVendorMatcher(&excluded[i], hostSpecific) {
+			return false
+		}
+	}
+	return true
+}
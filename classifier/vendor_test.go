@@ -0,0 +1,168 @@
+package classifier
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestCanonicalVendor(t *testing.T) {
+	SetVendorAliases(map[string]string{
+		"Dell Inc.": "Dell",
+		"DELL":      "Dell",
+	})
+	defer SetVendorAliases(nil)
+
+	assert.Equal(t, "Dell", canonicalVendor("Dell Inc."))
+	assert.Equal(t, "Dell", canonicalVendor("  dell   inc. "))
+	assert.Equal(t, "Dell", canonicalVendor("Dell"))
+	assert.Equal(t, "Lenovo", canonicalVendor(" Lenovo "))
+}
+
+func TestLoadVendorAliases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vendor-aliases")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "vendor-aliases.yaml")
+	err = ioutil.WriteFile(path, []byte("Dell Inc.: Dell\nHewlett-Packard: HPE\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliases, err := LoadVendorAliases(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Dell Inc.": "Dell", "Hewlett-Packard": "HPE"}, aliases)
+
+	err = ioutil.WriteFile(path, []byte("- Dell\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadVendorAliases(path)
+	assert.Error(t, err)
+
+	_, err = LoadVendorAliases(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestVendorAliases(t *testing.T) {
+	SetVendorAliases(map[string]string{
+		"Dell Inc.":       "Dell",
+		"Dell":            "Dell",
+		"Hewlett-Packard": "HPE",
+		"HP":              "HPE",
+	})
+	defer SetVendorAliases(nil)
+
+	testCases := []struct {
+		Scenario string
+		Rule     hwcc.HardwareCharacteristics
+		Actual   string
+		Expected bool
+	}{
+		{
+			Scenario: "alias-matched",
+			Rule: hwcc.HardwareCharacteristics{
+				SystemVendor: &hwcc.SystemVendor{
+					Manufacturer: "Dell Inc.",
+				},
+			},
+			Actual:   "DELL",
+			Expected: true,
+		},
+		{
+			Scenario: "canonical-matched",
+			Rule: hwcc.HardwareCharacteristics{
+				SystemVendor: &hwcc.SystemVendor{
+					Manufacturer: "HPE",
+				},
+			},
+			Actual:   "Hewlett-Packard",
+			Expected: true,
+		},
+		{
+			Scenario: "case-folded",
+			Rule: hwcc.HardwareCharacteristics{
+				SystemVendor: &hwcc.SystemVendor{
+					Manufacturer: "lenovo",
+				},
+			},
+			Actual:   "LENOVO",
+			Expected: true,
+		},
+		{
+			Scenario: "other-vendor",
+			Rule: hwcc.HardwareCharacteristics{
+				SystemVendor: &hwcc.SystemVendor{
+					Manufacturer: "Dell",
+				},
+			},
+			Actual:   "HP",
+			Expected: false,
+		},
+		{
+			Scenario: "raw-prefix-matched",
+			Rule: hwcc.HardwareCharacteristics{
+				SystemVendor: &hwcc.SystemVendor{
+					Manufacturer:          "hewlett",
+					ManufacturerMatchType: hwcc.MatchTypePrefix,
+				},
+			},
+			Actual:   "Hewlett-Packard",
+			Expected: true,
+		},
+		{
+			Scenario: "canonical-excluded",
+			Rule: hwcc.HardwareCharacteristics{
+				SystemVendor: &hwcc.SystemVendor{
+					ExcludedManufacturers: []hwcc.StringMatcher{{Value: "HPE"}},
+				},
+			},
+			Actual:   "HP",
+			Expected: false,
+		},
+		{
+			Scenario: "bios-alias-matched",
+			Rule: hwcc.HardwareCharacteristics{
+				Firmware: &hwcc.Firmware{
+					BIOS: hwcc.BIOS{
+						Vendor: "Dell",
+					},
+				},
+			},
+			Actual:   "Dell Inc.",
+			Expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			profile := hwcc.HardwareClassification{
+				Spec: hwcc.HardwareClassificationSpec{
+					HardwareCharacteristics: tc.Rule,
+				},
+			}
+			host := bmh.BareMetalHost{
+				Status: bmh.BareMetalHostStatus{
+					HardwareDetails: &bmh.HardwareDetails{
+						SystemVendor: bmh.HardwareSystemVendor{
+							Manufacturer: tc.Actual,
+						},
+						Firmware: bmh.Firmware{
+							BIOS: bmh.BIOS{
+								Vendor: tc.Actual,
+							},
+						},
+					},
+				},
+			}
+			assert.Equal(t, tc.Expected, ProfileMatchesHost(&profile, &host))
+		})
+	}
+}
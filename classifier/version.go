@@ -0,0 +1,163 @@
+package classifier
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// versionPattern finds dot separated versions in the strings
+	// reported by the vendors, e.g. "2.42" in "U30 v2.42 (03/15/2021)"
+	// or "2.50" in "IVE164E-2.50".
+	versionPattern = regexp.MustCompile(`\d+(?:[._-][0-9A-Za-z]+)+`)
+	// bareVersionPattern finds versions without separators, e.g. "42"
+	// in "P42".
+	bareVersionPattern = regexp.MustCompile(`\d+[0-9A-Za-z]*`)
+	// commentPattern finds parenthesized comments such as release
+	// dates.
+	commentPattern = regexp.MustCompile(`\([^)]*\)`)
+	// segmentPattern splits a part of a version into its numeric and
+	// alphabetic segments, e.g. "10a" into "10" and "a".
+	segmentPattern = regexp.MustCompile(`\d+|[A-Za-z]+`)
+)
+
+// version is a normalized firmware version, e.g. "2.10a" is
+// [[2], [10, a]]
+type version [][]string
+
+// parseVersion normalizes the version strings of the common BIOS
+// vendors
+func parseVersion(raw string) (version, error) {
+	stripped := commentPattern.ReplaceAllString(raw, " ")
+	found := versionPattern.FindString(stripped)
+	if found == "" {
+		found = bareVersionPattern.FindString(stripped)
+	}
+	if found == "" {
+		return nil, fmt.Errorf("no version found in %q", raw)
+	}
+
+	result := version{}
+	for _, part := range strings.FieldsFunc(found, isVersionSeparator) {
+		result = append(result, segmentPattern.FindAllString(strings.ToLower(part), -1))
+	}
+	return result, nil
+}
+
+func isVersionSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '_'
+}
+
+// compareVersions returns -1, 0 or 1 when a is lower than, equal to or
+// greater than b. Missing parts are treated as 0, so "2.1" equals
+// "2.1.0".
+func compareVersions(a, b version) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if result := compareVersionParts(versionPart(a, i), versionPart(b, i)); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func versionPart(v version, i int) []string {
+	if i < len(v) {
+		return v[i]
+	}
+	return []string{"0"}
+}
+
+// compareVersionParts compares the segments of a part, numbers by
+// value and letters alphabetically. A number sorts after letters and a
+// longer part after its prefix, so "10" < "10a" < "11".
+func compareVersionParts(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNum, aErr := strconv.ParseUint(a[i], 10, 64)
+		bNum, bErr := strconv.ParseUint(b[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				return compareOrder(aNum < bNum)
+			}
+		case aErr == nil:
+			return 1
+		case bErr == nil:
+			return -1
+		default:
+			if a[i] != b[i] {
+				return compareOrder(a[i] < b[i])
+			}
+		}
+	}
+	if len(a) != len(b) {
+		return compareOrder(len(a) < len(b))
+	}
+	return 0
+}
+
+func compareOrder(lower bool) int {
+	if lower {
+		return -1
+	}
+	return 1
+}
+
+// versionConstraint is a single comparison such as ">=2.10.0"
+type versionConstraint struct {
+	operator string
+	version  version
+}
+
+// versionOperators are ordered so that the longer operators are tried
+// first
+var versionOperators = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+// parseVersionConstraints parses a comma separated list of version
+// comparisons, e.g. ">=2.10.0, <3.0, !=2.12.1". A version without an
+// operator must be equal.
+func parseVersionConstraints(expression string) ([]versionConstraint, error) {
+	constraints := []versionConstraint{}
+	for _, item := range strings.Split(expression, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return nil, fmt.Errorf("empty constraint in %q", expression)
+		}
+		operator := "="
+		for _, op := range versionOperators {
+			if strings.HasPrefix(item, op) {
+				operator = op
+				item = strings.TrimSpace(strings.TrimPrefix(item, op))
+				break
+			}
+		}
+		v, err := parseVersion(item)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid constraint in %q", expression)
+		}
+		constraints = append(constraints, versionConstraint{operator: operator, version: v})
+	}
+	return constraints, nil
+}
+
+// check reports whether the version satisfies the constraint
+func (c versionConstraint) check(v version) bool {
+	result := compareVersions(v, c.version)
+	switch c.operator {
+	case ">=":
+		return result >= 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case "<":
+		return result < 0
+	case "!=":
+		return result != 0
+	default:
+		return result == 0
+	}
+}
@@ -0,0 +1,106 @@
+package classifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Raw      string
+		Expected version
+	}{
+		{
+			Scenario: "dell",
+			Raw:      "2.10.2",
+			Expected: version{{"2"}, {"10"}, {"2"}},
+		},
+		{
+			Scenario: "hpe",
+			Raw:      "U30 v2.42 (03/15/2021)",
+			Expected: version{{"2"}, {"42"}},
+		},
+		{
+			Scenario: "lenovo",
+			Raw:      "IVE164E-2.50",
+			Expected: version{{"2"}, {"50"}},
+		},
+		{
+			Scenario: "alphanumeric",
+			Raw:      "3.4a",
+			Expected: version{{"3"}, {"4", "a"}},
+		},
+		{
+			Scenario: "seabios",
+			Raw:      "1.13.0-1ubuntu1",
+			Expected: version{{"1"}, {"13"}, {"0"}, {"1", "ubuntu", "1"}},
+		},
+		{
+			Scenario: "bare",
+			Raw:      "P89",
+			Expected: version{{"89"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			actual, err := parseVersion(tc.Raw)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+
+	_, err := parseVersion("")
+	assert.Error(t, err)
+	_, err = parseVersion("unknown")
+	assert.Error(t, err)
+}
+
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		A        string
+		B        string
+		Expected int
+	}{
+		{A: "2.10.2", B: "2.9.9", Expected: 1},
+		{A: "2.1", B: "2.1.0", Expected: 0},
+		{A: "1.3", B: "1.4.6", Expected: -1},
+		{A: "U30 v2.42 (03/15/2021)", B: "2.40", Expected: 1},
+		{A: "3.4", B: "3.4a", Expected: -1},
+		{A: "3.4b", B: "3.4a", Expected: 1},
+		{A: "3.5", B: "3.4a", Expected: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.A+"/"+tc.B, func(t *testing.T) {
+			a, err := parseVersion(tc.A)
+			assert.NoError(t, err)
+			b, err := parseVersion(tc.B)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, compareVersions(a, b))
+		})
+	}
+}
+
+func TestParseVersionConstraints(t *testing.T) {
+	constraints, err := parseVersionConstraints(">=2.10.0, <3.0, !=2.12.1")
+	assert.NoError(t, err)
+	assert.Len(t, constraints, 3)
+
+	_, err = parseVersionConstraints(">=2.10.0,,<3.0")
+	assert.Error(t, err)
+	_, err = parseVersionConstraints(">=latest")
+	assert.Error(t, err)
+}
+
+func TestCheckVersion(t *testing.T) {
+	assert.True(t, checkVersion("", "", "", "1.5.6"))
+	assert.True(t, checkVersion("1.4.6", "", "", "1.5.6"))
+	assert.False(t, checkVersion("", "1.4.6", "", "1.5.6"))
+	assert.True(t, checkVersion("", "", ">=2.10.0, <3.0, !=2.12.1", "2.12.0"))
+	assert.False(t, checkVersion("", "", ">=2.10.0, <3.0, !=2.12.1", "2.12.1"))
+	assert.False(t, checkVersion("", "", ">=2.10.0, <3.0, !=2.12.1", "3.0.1"))
+	assert.True(t, checkVersion("", "", ">=2.40", "U30 v2.42 (03/15/2021)"))
+	assert.False(t, checkVersion("", "", ">=2.40", ""))
+	assert.False(t, checkVersion("", "", ">=", "2.10"))
+}
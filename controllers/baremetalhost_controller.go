@@ -18,12 +18,15 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -36,7 +39,12 @@ import (
 
 const (
 	defaultLabelName  = "hardwareclassification.metal3.io/"
+	clusterLabelName  = "clusterhardwareclassification.metal3.io/"
 	defaultLabelValue = "matches"
+
+	// scoreAnnotationPrefix is added to the label key of a profile with
+	// scoring to give the key of the score annotation
+	scoreAnnotationPrefix = "score."
 )
 
 // BareMetalHostReconciler reconciles a BareMetalHost object
@@ -44,6 +52,16 @@ type BareMetalHostReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+
+	// ClusterProfiles enables the cluster-scoped profiles, which need
+	// the hosts of all of the namespaces to be watched
+	ClusterProfiles bool
+	// ConfigMaps is the source of the events of the ConfigMaps holding
+	// serial numbers
+	ConfigMaps source.Source
+	// ConfigMapLister reads the ConfigMaps holding serial numbers from
+	// the informer behind ConfigMaps
+	ConfigMapLister corelisters.ConfigMapLister
 }
 
 func (r *BareMetalHostReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
@@ -82,30 +100,26 @@ func (r *BareMetalHostReconciler) Reconcile(req ctrl.Request) (ctrl.Result, erro
 		return ctrl.Result{}, errors.Wrap(err, "could not fetch classification profiles")
 	}
 
-	changed := false
-	for _, profile := range profileList.Items {
-		labelKey, labelValue := getLabelDetails(&profile)
-
-		switch {
-		case !profile.DeletionTimestamp.IsZero():
-			logger.Info("profile is being deleted", "profile", profile.Name)
-			changed = deleteLabel(host, labelKey) || changed
-			if changed {
-				logger.Info("removed label", "name", labelKey, "value", labelValue)
-			}
-		case !classifier.ProfileMatchesHost(&profile, host):
-			changed = deleteLabel(host, labelKey) || changed
-			if changed {
-				logger.Info("removed label", "name", labelKey, "value", labelValue)
-			}
-		default:
-			changed = setLabel(host, labelKey, labelValue) || changed
-			if changed {
-				logger.Info("set label", "name", labelKey, "value", labelValue)
-			}
+	results := []*classification{}
+	for i := range profileList.Items {
+		profile := &profileList.Items[i]
+		labelKey, labelValue := getLabelDetails(profile)
+		getBase := namespacedBaseProfiles(context.TODO(), r.Client, host.Namespace)
+		results = append(results, r.classifyHost(logger, host, profile, getBase, labelKey, labelValue))
+	}
+
+	if r.ClusterProfiles {
+		clusterResults, err := r.classifyHostWithClusterProfiles(logger, host)
+		if err != nil {
+			return ctrl.Result{}, err
 		}
+		results = append(results, clusterResults...)
 	}
 
+	applyRequiredProfiles(logger, results)
+	applyExclusiveGroups(logger, results)
+	changed := applyClassifications(logger, host, results)
+
 	if changed {
 		if err := r.Update(context.TODO(), host); err != nil {
 			return ctrl.Result{}, errors.Wrap(err,
@@ -116,6 +130,232 @@ func (r *BareMetalHostReconciler) Reconcile(req ctrl.Request) (ctrl.Result, erro
 	return ctrl.Result{}, nil
 }
 
+// classification is the result of classifying the host with a profile
+type classification struct {
+	profile    *hwcc.HardwareClassification
+	cluster    bool
+	labelKey   string
+	labelValue string
+	matched    bool
+	// score is the fitness score of the host, which is recorded in an
+	// annotation for the profiles with scoring
+	score  int
+	scored bool
+}
+
+// classifyHostWithClusterProfiles classifies the host with the cluster
+// profiles selecting its namespace
+func (r *BareMetalHostReconciler) classifyHostWithClusterProfiles(logger logr.Logger, host *bmh.BareMetalHost) ([]*classification, error) {
+	clusterProfileList := hwcc.ClusterHardwareClassificationList{}
+	err := r.List(context.TODO(), &clusterProfileList)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch cluster classification profiles")
+	}
+	if len(clusterProfileList.Items) == 0 {
+		return nil, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	err = r.Get(context.TODO(), types.NamespacedName{Name: host.Namespace}, namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load the namespace of the host")
+	}
+
+	getBase := clusterBaseProfiles(context.TODO(), r.Client, host.Namespace)
+	results := []*classification{}
+	for i := range clusterProfileList.Items {
+		clusterProfile := &clusterProfileList.Items[i]
+		labelKey, labelValue := getClusterLabelDetails(clusterProfile)
+		profile := clusterProfile.Profile(host.Namespace)
+
+		var result *classification
+		if namespaceSelected(clusterProfile, namespace) {
+			result = r.classifyHost(logger, host, profile, getBase, labelKey, labelValue)
+		} else {
+			result = &classification{profile: profile, labelKey: labelKey, labelValue: labelValue}
+		}
+		result.cluster = true
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// classifyHost checks whether the effective profile, merged with its
+// base profiles, matches the host
+func (r *BareMetalHostReconciler) classifyHost(logger logr.Logger, host *bmh.BareMetalHost, profile *hwcc.HardwareClassification, getBase baseProfileGetter, labelKey, labelValue string) *classification {
+	result := &classification{profile: profile, labelKey: labelKey, labelValue: labelValue}
+
+	if !profile.DeletionTimestamp.IsZero() {
+		logger.Info("profile is being deleted", "profile", profile.Name)
+		return result
+	}
+
+	resolved, err := resolveBaseProfile(profile, getBase)
+	if err == nil {
+		resolved, err = resolveSerialNumbers(r.ConfigMapLister, resolved)
+	}
+	if err != nil {
+		logger.Info("could not resolve profile", "profile", profile.Name, "error", err.Error())
+		return result
+	}
+	if err := classifier.ValidateProfile(resolved); err != nil {
+		logger.Info("invalid profile", "profile", profile.Name, "error", err.Error())
+		return result
+	}
+
+	// Only the hosts matching the hard constraints and the host
+	// selector are scored, the others have no score annotation.
+	if !classifier.ProfileMatchesHost(resolved, host) {
+		return result
+	}
+	result.score, result.matched = classifier.ScoreHost(resolved, host)
+	result.scored = resolved.Spec.Scoring != nil
+	if result.matched && len(profile.Spec.Tiers) > 0 {
+		// The label value of a profile with tiers is the highest tier
+		// met by the host.
+		tier := classifier.HostTier(resolved, host)
+		result.matched = tier != ""
+		result.labelValue = tier
+	}
+	return result
+}
+
+// applyRequiredProfiles unmatches the profiles requiring a profile the
+// host does not match, using the results of the classifier rather than
+// the labels of the host. Required profiles which are missing are not
+// matched. Namespaced profiles require namespaced profiles and cluster
+// profiles require cluster profiles.
+func applyRequiredProfiles(logger logr.Logger, results []*classification) {
+	type profileKey struct {
+		cluster bool
+		name    string
+	}
+	byName := map[profileKey]*classification{}
+	for _, result := range results {
+		byName[profileKey{result.cluster, result.profile.Name}] = result
+	}
+
+	// Repeat until no profile changes, so that the profiles requiring
+	// an unmatched profile through other profiles are unmatched too.
+	for changed := true; changed; {
+		changed = false
+		for _, result := range results {
+			if !result.matched {
+				continue
+			}
+			for _, name := range result.profile.Spec.RequiredProfiles {
+				required, ok := byName[profileKey{result.cluster, name}]
+				if ok && required.matched {
+					continue
+				}
+				logger.Info("required profile not matched",
+					"profile", result.profile.Name,
+					"requiredProfile", name,
+					"found", ok,
+				)
+				result.matched = false
+				changed = true
+				break
+			}
+		}
+	}
+}
+
+// applyExclusiveGroups keeps only the matching profile with the highest
+// priority of each exclusive group as matched. Ties are broken by the
+// score, then by the profile name, and namespaced profiles win over
+// cluster profiles.
+func applyExclusiveGroups(logger logr.Logger, results []*classification) {
+	winners := map[string]*classification{}
+	for _, result := range results {
+		group := result.profile.Spec.ExclusiveGroup
+		if group == "" || !result.matched {
+			continue
+		}
+		if winner, ok := winners[group]; !ok || higherPriority(result, winner) {
+			winners[group] = result
+		}
+	}
+
+	for _, result := range results {
+		group := result.profile.Spec.ExclusiveGroup
+		if group == "" || !result.matched || winners[group] == result {
+			continue
+		}
+		logger.Info("profile has a lower priority in its exclusive group",
+			"profile", result.profile.Name,
+			"group", group,
+			"selectedProfile", winners[group].profile.Name,
+		)
+		result.matched = false
+	}
+}
+
+func higherPriority(a, b *classification) bool {
+	if a.profile.Spec.Priority != b.profile.Spec.Priority {
+		return a.profile.Spec.Priority > b.profile.Spec.Priority
+	}
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	if a.profile.Name != b.profile.Name {
+		return a.profile.Name < b.profile.Name
+	}
+	return !a.cluster && b.cluster
+}
+
+// applyClassifications sets the labels of the matched profiles on the
+// host and removes the labels of the other profiles, including the
+// class labels of the exclusive groups. The scores of the profiles with
+// scoring are recorded in annotations. Returns true if the labels or
+// the annotations of the host changed.
+func applyClassifications(logger logr.Logger, host *bmh.BareMetalHost, results []*classification) bool {
+	changed := false
+	classLabels := map[string]string{}
+	for _, result := range results {
+		scoreKey := scoreAnnotationPrefix + result.labelKey
+		if result.scored {
+			if setAnnotation(host, scoreKey, strconv.Itoa(result.score)) {
+				logger.Info("set score", "name", scoreKey, "score", result.score)
+				changed = true
+			}
+		} else if deleteAnnotation(host, scoreKey) {
+			logger.Info("removed score", "name", scoreKey)
+			changed = true
+		}
+
+		if result.matched {
+			if setLabel(host, result.labelKey, result.labelValue) {
+				logger.Info("set label", "name", result.labelKey, "value", result.labelValue)
+				changed = true
+			}
+			if classLabel := result.profile.Spec.ClassLabel; classLabel != "" && result.profile.Spec.ExclusiveGroup != "" {
+				classLabels[classLabel] = result.profile.Name
+			}
+		} else if deleteLabel(host, result.labelKey) {
+			logger.Info("removed label", "name", result.labelKey, "value", result.labelValue)
+			changed = true
+		}
+	}
+
+	for _, result := range results {
+		classLabel := result.profile.Spec.ClassLabel
+		if classLabel == "" || result.profile.Spec.ExclusiveGroup == "" {
+			continue
+		}
+		if value, ok := classLabels[classLabel]; ok {
+			if setLabel(host, classLabel, value) {
+				logger.Info("set class label", "name", classLabel, "value", value)
+				changed = true
+			}
+		} else if deleteLabel(host, classLabel) {
+			logger.Info("removed class label", "name", classLabel)
+			changed = true
+		}
+	}
+	return changed
+}
+
 func getLabelDetails(profile *hwcc.HardwareClassification) (key, value string) {
 	key = defaultLabelName + profile.Name
 	labels := profile.GetLabels()
@@ -130,6 +370,14 @@ func getLabelDetails(profile *hwcc.HardwareClassification) (key, value string) {
 	return
 }
 
+// getClusterLabelDetails returns the label of the cluster profile,
+// using a different prefix so it does not clash with the label of a
+// namespaced profile with the same name
+func getClusterLabelDetails(profile *hwcc.ClusterHardwareClassification) (key, value string) {
+	_, value = getLabelDetails(profile.Profile(""))
+	return clusterLabelName + profile.Name, value
+}
+
 func deleteLabel(host *bmh.BareMetalHost, labelKey string) bool {
 	labels := host.GetLabels()
 
@@ -166,18 +414,57 @@ func setLabel(host *bmh.BareMetalHost, labelKey string, labelValue string) bool
 	return true
 }
 
+func deleteAnnotation(host *bmh.BareMetalHost, key string) bool {
+	annotations := host.GetAnnotations()
+	if _, ok := annotations[key]; !ok {
+		return false
+	}
+
+	delete(annotations, key)
+	host.SetAnnotations(annotations)
+	return true
+}
+
+func setAnnotation(host *bmh.BareMetalHost, key string, value string) bool {
+	annotations := host.GetAnnotations()
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	if val, ok := annotations[key]; ok && val == value {
+		return false
+	}
+
+	annotations[key] = value
+	host.SetAnnotations(annotations)
+	return true
+}
+
 func (r *BareMetalHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	mapper := hostMapper{
 		client: mgr.GetClient(),
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&bmh.BareMetalHost{}).
 		Named("baremetalhost").
 		Watches(&source.Kind{Type: &hwcc.HardwareClassification{}},
 			&handler.EnqueueRequestsFromMapFunc{ToRequests: &mapper}).
-		Complete(r)
+		Watches(r.ConfigMaps,
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: &configMapHostMapper{
+				hostMapper:      mapper,
+				clusterProfiles: r.ClusterProfiles,
+			}})
+	if r.ClusterProfiles {
+		builder = builder.
+			Watches(&source.Kind{Type: &hwcc.ClusterHardwareClassification{}},
+				&handler.EnqueueRequestsFromMapFunc{ToRequests: &mapper}).
+			Watches(&source.Kind{Type: &corev1.Namespace{}},
+				&handler.EnqueueRequestsFromMapFunc{ToRequests: &namespaceHostMapper{hostMapper: mapper}})
+	}
+	return builder.Complete(r)
 }
 
 type hostMapper struct {
@@ -189,11 +476,20 @@ func (m *hostMapper) Map(obj handler.MapObject) []ctrl.Request {
 		WithValues("HardwareClassification",
 			fmt.Sprintf("%s/%s", obj.Meta.GetNamespace(), obj.Meta.GetName()))
 
+	// We only want to apply profiles to hosts in the same namespace,
+	// and cluster profiles, which have no namespace, to the hosts in
+	// all of the namespaces. The host selector of the profile is not
+	// used here, because hosts which are no longer selected need their
+	// label removed.
+	return m.hostRequests(obj.Meta.GetNamespace(), log)
+}
+
+// hostRequests requests the hosts in the namespace, or in all of the
+// namespaces if it is empty
+func (m *hostMapper) hostRequests(namespace string, log logr.Logger) []ctrl.Request {
 	bmhHostList := bmh.BareMetalHostList{}
 	opts := &client.ListOptions{
-		// We only want to apply profiles to hosts in the same
-		// namespace.
-		Namespace: obj.Meta.GetNamespace(),
+		Namespace: namespace,
 	}
 	err := m.client.List(context.TODO(), &bmhHostList, opts)
 	if err != nil {
@@ -213,3 +509,63 @@ func (m *hostMapper) Map(obj handler.MapObject) []ctrl.Request {
 	}
 	return requests
 }
+
+// configMapHostMapper requests the hosts in the namespace of a
+// ConfigMap holding serial numbers for one of the profiles of the
+// namespace or, when they are enabled, one of the cluster profiles
+type configMapHostMapper struct {
+	hostMapper
+	clusterProfiles bool
+}
+
+func (m *configMapHostMapper) Map(obj handler.MapObject) []ctrl.Request {
+	log := ctrl.Log.WithName("controllers").WithName("BareMetalHost").WithName("mapper").
+		WithValues("ConfigMap",
+			fmt.Sprintf("%s/%s", obj.Meta.GetNamespace(), obj.Meta.GetName()))
+
+	profileList := hwcc.HardwareClassificationList{}
+	opts := &client.ListOptions{
+		Namespace: obj.Meta.GetNamespace(),
+	}
+	err := m.client.List(context.TODO(), &profileList, opts)
+	if err != nil {
+		log.Error(err, "could not fetch classification profiles")
+		return nil
+	}
+
+	for i := range profileList.Items {
+		if referencesConfigMap(&profileList.Items[i], obj.Meta.GetName()) {
+			return m.hostMapper.Map(obj)
+		}
+	}
+	if !m.clusterProfiles {
+		return nil
+	}
+
+	// Cluster profiles read the ConfigMap from the namespace of each
+	// host they classify.
+	clusterProfileList := hwcc.ClusterHardwareClassificationList{}
+	err = m.client.List(context.TODO(), &clusterProfileList)
+	if err != nil {
+		log.Error(err, "could not fetch cluster classification profiles")
+		return nil
+	}
+	for i := range clusterProfileList.Items {
+		if referencesConfigMap(clusterProfileList.Items[i].Profile(""), obj.Meta.GetName()) {
+			return m.hostMapper.Map(obj)
+		}
+	}
+	return nil
+}
+
+// namespaceHostMapper requests the hosts in a namespace when its labels,
+// used by the namespace selectors of cluster profiles, change
+type namespaceHostMapper struct {
+	hostMapper
+}
+
+func (m *namespaceHostMapper) Map(obj handler.MapObject) []ctrl.Request {
+	log := ctrl.Log.WithName("controllers").WithName("BareMetalHost").WithName("mapper").
+		WithValues("Namespace", obj.Meta.GetName())
+	return m.hostRequests(obj.Meta.GetName(), log)
+}
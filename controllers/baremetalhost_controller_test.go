@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
@@ -174,3 +177,262 @@ func TestDeleteLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyExclusiveGroups(t *testing.T) {
+	newResult := func(name, group string, priority int32, matched bool) *classification {
+		return &classification{
+			profile: &hwcc.HardwareClassification{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: hwcc.HardwareClassificationSpec{
+					ExclusiveGroup: group,
+					Priority:       priority,
+				},
+			},
+			matched: matched,
+		}
+	}
+
+	testCases := []struct {
+		Scenario string
+		Results  []*classification
+		Scores   []int
+		Expected []bool
+	}{
+		{
+			Scenario: "no-group",
+			Results: []*classification{
+				newResult("a", "", 0, true),
+				newResult("b", "", 10, true),
+			},
+			Expected: []bool{true, true},
+		},
+		{
+			Scenario: "highest-priority",
+			Results: []*classification{
+				newResult("a", "class", 10, true),
+				newResult("b", "class", 20, true),
+				newResult("c", "class", 30, false),
+			},
+			Expected: []bool{false, true, false},
+		},
+		{
+			Scenario: "tie-broken-by-name",
+			Results: []*classification{
+				newResult("b", "class", 10, true),
+				newResult("a", "class", 10, true),
+			},
+			Expected: []bool{false, true},
+		},
+		{
+			Scenario: "tie-broken-by-score",
+			Results: []*classification{
+				newResult("a", "class", 10, true),
+				newResult("b", "class", 10, true),
+			},
+			Scores:   []int{50, 80},
+			Expected: []bool{false, true},
+		},
+		{
+			Scenario: "separate-groups",
+			Results: []*classification{
+				newResult("a", "class", 10, true),
+				newResult("b", "class", 20, true),
+				newResult("c", "rack", 0, true),
+				newResult("d", "", 0, true),
+			},
+			Expected: []bool{false, true, true, true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			for i, score := range tc.Scores {
+				tc.Results[i].score = score
+			}
+			applyExclusiveGroups(ctrl.Log.WithName("test"), tc.Results)
+			for i, result := range tc.Results {
+				assert.Equal(t, tc.Expected[i], result.matched, result.profile.Name)
+			}
+		})
+	}
+}
+
+func TestExclusiveGroupLabels(t *testing.T) {
+	newProfile := func(name string, priority int32, minimumCount int) *hwcc.HardwareClassification {
+		return &hwcc.HardwareClassification{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "metal3",
+			},
+			Spec: hwcc.HardwareClassificationSpec{
+				HardwareCharacteristics: hwcc.HardwareCharacteristics{
+					Cpu: &hwcc.Cpu{MinimumCount: minimumCount},
+				},
+				ExclusiveGroup: "class",
+				Priority:       priority,
+				ClassLabel:     "hardwareclassification.metal3.io/class",
+			},
+		}
+	}
+
+	testCases := []struct {
+		Scenario string
+		CPUCount int
+		Labels   map[string]string
+		Expected map[string]string
+	}{
+		{
+			Scenario: "large",
+			CPUCount: 64,
+			Labels: map[string]string{
+				"hardwareclassification.metal3.io/small": "matches",
+			},
+			Expected: map[string]string{
+				"hardwareclassification.metal3.io/large": "matches",
+				"hardwareclassification.metal3.io/class": "large",
+			},
+		},
+		{
+			Scenario: "small",
+			CPUCount: 16,
+			Expected: map[string]string{
+				"hardwareclassification.metal3.io/small": "matches",
+				"hardwareclassification.metal3.io/class": "small",
+			},
+		},
+		{
+			Scenario: "none",
+			CPUCount: 4,
+			Labels: map[string]string{
+				"hardwareclassification.metal3.io/class": "small",
+				"rack":                                   "r12",
+			},
+			Expected: map[string]string{
+				"rack": "r12",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := testHost("metal3", "host-0", tc.Labels, tc.CPUCount)
+			c := newTestClient(newProfile("large", 20, 32), newProfile("small", 10, 8), host)
+			r := &BareMetalHostReconciler{
+				Client: c,
+				Log:    ctrl.Log.WithName("test"),
+			}
+			key := types.NamespacedName{Namespace: "metal3", Name: "host-0"}
+			_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+
+			updated := &bmh.BareMetalHost{}
+			assert.NoError(t, c.Get(context.TODO(), key, updated))
+			assert.Equal(t, tc.Expected, updated.Labels)
+		})
+	}
+}
+
+func TestScoreAnnotation(t *testing.T) {
+	newProfile := func(threshold int32, selector *metav1.LabelSelector) *hwcc.HardwareClassification {
+		return &hwcc.HardwareClassification{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "compute",
+				Namespace: "metal3",
+			},
+			Spec: hwcc.HardwareClassificationSpec{
+				HardwareCharacteristics: hwcc.HardwareCharacteristics{
+					Cpu: &hwcc.Cpu{MinimumCount: 8},
+					Blocks: []hwcc.NamedCharacteristicsBlock{
+						{
+							Name: "small",
+							CharacteristicsBlock: hwcc.CharacteristicsBlock{
+								Cpu: &hwcc.Cpu{MaximumCount: 16},
+							},
+						},
+						{
+							Name: "medium",
+							CharacteristicsBlock: hwcc.CharacteristicsBlock{
+								Cpu: &hwcc.Cpu{MaximumCount: 32},
+							},
+						},
+					},
+				},
+				HostSelector: selector,
+				Scoring: &hwcc.Scoring{
+					SoftConstraints: []hwcc.SoftConstraint{
+						{
+							Block:  "small",
+							Weight: 3,
+						},
+						{
+							Block: "medium",
+						},
+					},
+					Threshold: threshold,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Scenario            string
+		CPUCount            int
+		Threshold           int32
+		HostSelector        *metav1.LabelSelector
+		ExpectedLabels      map[string]string
+		ExpectedAnnotations map[string]string
+	}{
+		{
+			Scenario:  "closest-fit",
+			CPUCount:  16,
+			Threshold: 50,
+			ExpectedLabels: map[string]string{
+				"hardwareclassification.metal3.io/compute": "matches",
+			},
+			ExpectedAnnotations: map[string]string{
+				"score.hardwareclassification.metal3.io/compute": "100",
+			},
+		},
+		{
+			Scenario:  "below-threshold",
+			CPUCount:  32,
+			Threshold: 50,
+			ExpectedAnnotations: map[string]string{
+				"score.hardwareclassification.metal3.io/compute": "25",
+			},
+		},
+		{
+			Scenario:  "unmatched",
+			CPUCount:  4,
+			Threshold: 0,
+		},
+		{
+			Scenario:  "not-selected",
+			CPUCount:  16,
+			Threshold: 0,
+			HostSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "compute"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := testHost("metal3", "host-0", nil, tc.CPUCount)
+			c := newTestClient(newProfile(tc.Threshold, tc.HostSelector), host)
+			r := &BareMetalHostReconciler{
+				Client: c,
+				Log:    ctrl.Log.WithName("test"),
+			}
+			key := types.NamespacedName{Namespace: "metal3", Name: "host-0"}
+			_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+
+			updated := &bmh.BareMetalHost{}
+			assert.NoError(t, c.Get(context.TODO(), key, updated))
+			if tc.ExpectedLabels == nil {
+				assert.Empty(t, updated.Labels)
+			} else {
+				assert.Equal(t, tc.ExpectedLabels, updated.Labels)
+			}
+			assert.Equal(t, tc.ExpectedAnnotations, updated.Annotations)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// baseProfileGetter loads a base profile by name
+type baseProfileGetter func(name string) (*hwcc.HardwareClassification, error)
+
+// namespacedBaseProfiles loads the base profiles of the
+// HardwareClassifications in the namespace
+func namespacedBaseProfiles(ctx context.Context, c client.Client, namespace string) baseProfileGetter {
+	return func(name string) (*hwcc.HardwareClassification, error) {
+		profile := &hwcc.HardwareClassification{}
+		key := types.NamespacedName{Namespace: namespace, Name: name}
+		if err := c.Get(ctx, key, profile); err != nil {
+			return nil, errors.Wrapf(err, "could not load base profile %s", name)
+		}
+		return profile, nil
+	}
+}
+
+// clusterBaseProfiles loads the base profiles of the cluster profiles
+// applied to the namespace
+func clusterBaseProfiles(ctx context.Context, c client.Client, namespace string) baseProfileGetter {
+	return func(name string) (*hwcc.HardwareClassification, error) {
+		profile := &hwcc.ClusterHardwareClassification{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, profile); err != nil {
+			return nil, errors.Wrapf(err, "could not load base profile %s", name)
+		}
+		return profile.Profile(namespace), nil
+	}
+}
+
+// resolveBaseProfile returns a copy of the profile with the hardware
+// characteristics of its chain of base profiles merged under its own
+func resolveBaseProfile(profile *hwcc.HardwareClassification, getBase baseProfileGetter) (*hwcc.HardwareClassification, error) {
+	resolved := profile.DeepCopy()
+	chain := []string{profile.Name}
+	for base := profile.Spec.BaseProfile; base != ""; {
+		for _, name := range chain {
+			if name == base {
+				return nil, errors.Errorf("base profile reference cycle: %s -> %s",
+					strings.Join(chain, " -> "), base)
+			}
+		}
+		chain = append(chain, base)
+
+		baseProfile, err := getBase(base)
+		if err != nil {
+			return nil, err
+		}
+		merged, err := mergeCharacteristics(&baseProfile.Spec.HardwareCharacteristics,
+			&resolved.Spec.HardwareCharacteristics)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not merge base profile %s", base)
+		}
+		resolved.Spec.HardwareCharacteristics = *merged
+		base = baseProfile.Spec.BaseProfile
+	}
+	return resolved, nil
+}
+
+// mergeCharacteristics returns the base characteristics with the
+// fields set in the override replacing the base ones. Objects are
+// merged field by field, other values including lists are replaced.
+func mergeCharacteristics(base, override *hwcc.HardwareCharacteristics) (*hwcc.HardwareCharacteristics, error) {
+	baseFields, err := characteristicsFields(base)
+	if err != nil {
+		return nil, err
+	}
+	overrideFields, err := characteristicsFields(override)
+	if err != nil {
+		return nil, err
+	}
+	mergeFields(baseFields, overrideFields)
+
+	data, err := json.Marshal(baseFields)
+	if err != nil {
+		return nil, err
+	}
+	merged := &hwcc.HardwareCharacteristics{}
+	if err := json.Unmarshal(data, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func characteristicsFields(characteristics *hwcc.HardwareCharacteristics) (map[string]interface{}, error) {
+	data, err := json.Marshal(characteristics)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// mergedGroups lists the characteristics whose fields are merged one
+// by one, with the number of levels merged: firmware is merged down to
+// the fields of bios. The named blocks are merged by name, a block of
+// the override replacing the base block of the same name whole.
+// Everything else, such as not, allOf, anyOf, the string matchers and
+// the serial number lists, is replaced whole, as merging the fields of
+// a matcher or an expression would build one neither profile has.
+var mergedGroups = map[string]int{
+	"cpu":          1,
+	"disk":         1,
+	"nic":          1,
+	"ram":          1,
+	"systemVendor": 1,
+	"hostname":     1,
+	"firmware":     2,
+}
+
+func mergeFields(dst, src map[string]interface{}) {
+	for key, value := range src {
+		if key == "blocks" {
+			dst[key] = mergeBlocks(dst[key], value)
+			continue
+		}
+		mergeField(dst, key, value, mergedGroups[key])
+	}
+}
+
+func mergeBlocks(dst, src interface{}) interface{} {
+	dstBlocks, _ := dst.([]interface{})
+	srcBlocks, ok := src.([]interface{})
+	if !ok {
+		return src
+	}
+	merged := append([]interface{}{}, dstBlocks...)
+	for _, block := range srcBlocks {
+		name := blockName(block)
+		replaced := false
+		for i := range merged {
+			if blockName(merged[i]) == name {
+				merged[i] = block
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, block)
+		}
+	}
+	return merged
+}
+
+func blockName(block interface{}) interface{} {
+	if fields, ok := block.(map[string]interface{}); ok {
+		return fields["name"]
+	}
+	return nil
+}
+
+func mergeField(dst map[string]interface{}, key string, value interface{}, depth int) {
+	srcObject, srcOK := value.(map[string]interface{})
+	dstObject, dstOK := dst[key].(map[string]interface{})
+	if depth == 0 || !srcOK || !dstOK {
+		dst[key] = value
+		return
+	}
+	for field, fieldValue := range srcObject {
+		mergeField(dstObject, field, fieldValue, depth-1)
+	}
+}
+
+// inheritsFrom reports whether the profile inherits from the base
+// profile, directly or through other base profiles, given the base
+// profile of each profile by name
+func inheritsFrom(profile, base string, baseProfiles map[string]string) bool {
+	visited := map[string]bool{profile: true}
+	for name := baseProfiles[profile]; name != "" && !visited[name]; name = baseProfiles[name] {
+		if name == base {
+			return true
+		}
+		visited[name] = true
+	}
+	return false
+}
@@ -0,0 +1,339 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func inheritingProfile(name, base string, characteristics hwcc.HardwareCharacteristics) *hwcc.HardwareClassification {
+	return &hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "metal3",
+		},
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: characteristics,
+			BaseProfile:             base,
+		},
+	}
+}
+
+func TestResolveBaseProfile(t *testing.T) {
+	baseline := inheritingProfile("baseline", "", hwcc.HardwareCharacteristics{
+		Cpu: &hwcc.Cpu{
+			MinimumCount:    32,
+			MaximumCount:    64,
+			MinimumSpeedMHz: 2600,
+		},
+		Firmware: &hwcc.Firmware{
+			BIOS: hwcc.BIOS{
+				Vendor:            "Dell Inc.",
+				VersionConstraint: ">=2.10.0",
+			},
+		},
+	})
+	storage := inheritingProfile("storage", "baseline", hwcc.HardwareCharacteristics{
+		Cpu: &hwcc.Cpu{
+			MaximumCount: 128,
+		},
+		Disk: &hwcc.Disk{
+			MinimumCount: 8,
+		},
+	})
+	nvme := inheritingProfile("nvme", "storage", hwcc.HardwareCharacteristics{
+		Disk: &hwcc.Disk{
+			Type: hwcc.DiskTypeNVME,
+		},
+	})
+	getBase := namespacedBaseProfiles(context.TODO(), newTestClient(baseline, storage, nvme), "metal3")
+
+	resolved, err := resolveBaseProfile(nvme, getBase)
+	if assert.NoError(t, err) {
+		assert.Equal(t, hwcc.HardwareCharacteristics{
+			Cpu: &hwcc.Cpu{
+				MinimumCount:    32,
+				MaximumCount:    128,
+				MinimumSpeedMHz: 2600,
+			},
+			Disk: &hwcc.Disk{
+				Type:         hwcc.DiskTypeNVME,
+				MinimumCount: 8,
+			},
+			Firmware: &hwcc.Firmware{
+				BIOS: hwcc.BIOS{
+					Vendor:            "Dell Inc.",
+					VersionConstraint: ">=2.10.0",
+				},
+			},
+		}, resolved.Spec.HardwareCharacteristics)
+	}
+	assert.Nil(t, nvme.Spec.HardwareCharacteristics.Cpu, "the profile must not be modified")
+
+	_, err = resolveBaseProfile(inheritingProfile("orphan", "missing", hwcc.HardwareCharacteristics{}), getBase)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not load base profile missing")
+	}
+}
+
+func TestMergeCharacteristics(t *testing.T) {
+	testCases := []struct {
+		Scenario string
+		Base     hwcc.HardwareCharacteristics
+		Override hwcc.HardwareCharacteristics
+		Expected hwcc.HardwareCharacteristics
+	}{
+		{
+			Scenario: "group-fields-merged",
+			Base: hwcc.HardwareCharacteristics{
+				Ram: &hwcc.Ram{MinimumSizeGB: 64, MaximumSizeGB: 256},
+			},
+			Override: hwcc.HardwareCharacteristics{
+				Ram: &hwcc.Ram{MaximumSizeGB: 512},
+			},
+			Expected: hwcc.HardwareCharacteristics{
+				Ram: &hwcc.Ram{MinimumSizeGB: 64, MaximumSizeGB: 512},
+			},
+		},
+		{
+			Scenario: "bios-fields-merged",
+			Base: hwcc.HardwareCharacteristics{
+				Firmware: &hwcc.Firmware{BIOS: hwcc.BIOS{Vendor: "Dell Inc.", VersionConstraint: ">=2.10.0"}},
+			},
+			Override: hwcc.HardwareCharacteristics{
+				Firmware: &hwcc.Firmware{BIOS: hwcc.BIOS{VersionConstraint: ">=2.12.0"}},
+			},
+			Expected: hwcc.HardwareCharacteristics{
+				Firmware: &hwcc.Firmware{BIOS: hwcc.BIOS{Vendor: "Dell Inc.", VersionConstraint: ">=2.12.0"}},
+			},
+		},
+		{
+			Scenario: "string-matcher-replaced",
+			Base: hwcc.HardwareCharacteristics{
+				Cpu: &hwcc.Cpu{
+					MinimumCount: 32,
+					Model:        &hwcc.StringMatcher{Value: "Intel(R) Xeon(R) Gold*", MatchType: hwcc.MatchTypeGlob},
+				},
+			},
+			Override: hwcc.HardwareCharacteristics{
+				Cpu: &hwcc.Cpu{
+					Model: &hwcc.StringMatcher{Value: "AMD EPYC 7502 32-Core Processor"},
+				},
+			},
+			Expected: hwcc.HardwareCharacteristics{
+				Cpu: &hwcc.Cpu{
+					MinimumCount: 32,
+					Model:        &hwcc.StringMatcher{Value: "AMD EPYC 7502 32-Core Processor"},
+				},
+			},
+		},
+		{
+			Scenario: "not-replaced",
+			Base: hwcc.HardwareCharacteristics{
+				Not: &hwcc.CharacteristicsExpression{Block: "hpe"},
+			},
+			Override: hwcc.HardwareCharacteristics{
+				Not: &hwcc.CharacteristicsExpression{AnyOf: []string{"small"}},
+			},
+			Expected: hwcc.HardwareCharacteristics{
+				Not: &hwcc.CharacteristicsExpression{AnyOf: []string{"small"}},
+			},
+		},
+		{
+			Scenario: "any-of-entries-replaced",
+			Base: hwcc.HardwareCharacteristics{
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{Block: "large-ram"},
+					{Block: "many-cpus"},
+				},
+			},
+			Override: hwcc.HardwareCharacteristics{
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{Block: "small-ram"},
+				},
+			},
+			Expected: hwcc.HardwareCharacteristics{
+				AnyOf: []hwcc.CharacteristicsExpression{
+					{Block: "small-ram"},
+				},
+			},
+		},
+		{
+			Scenario: "blocks-merged-by-name",
+			Base: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{
+						Name: "small-ram",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Ram: &hwcc.Ram{MinimumSizeGB: 16, MaximumSizeGB: 128},
+						},
+					},
+					{
+						Name: "many-cpus",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Cpu: &hwcc.Cpu{MinimumCount: 64},
+						},
+					},
+				},
+			},
+			Override: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{
+						Name: "small-ram",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Ram: &hwcc.Ram{MaximumSizeGB: 64},
+						},
+					},
+					{
+						Name: "hpe",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							SystemVendor: &hwcc.SystemVendor{Manufacturer: "HPE"},
+						},
+					},
+				},
+			},
+			Expected: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{
+						Name: "small-ram",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Ram: &hwcc.Ram{MaximumSizeGB: 64},
+						},
+					},
+					{
+						Name: "many-cpus",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Cpu: &hwcc.Cpu{MinimumCount: 64},
+						},
+					},
+					{
+						Name: "hpe",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							SystemVendor: &hwcc.SystemVendor{Manufacturer: "HPE"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			merged, err := mergeCharacteristics(&tc.Base, &tc.Override)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.Expected, *merged)
+			}
+		})
+	}
+}
+
+func TestResolveBaseProfileCycle(t *testing.T) {
+	a := inheritingProfile("a", "b", hwcc.HardwareCharacteristics{})
+	b := inheritingProfile("b", "c", hwcc.HardwareCharacteristics{})
+	c := inheritingProfile("c", "a", hwcc.HardwareCharacteristics{})
+	self := inheritingProfile("self", "self", hwcc.HardwareCharacteristics{})
+	getBase := namespacedBaseProfiles(context.TODO(), newTestClient(a, b, c, self), "metal3")
+
+	_, err := resolveBaseProfile(a, getBase)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "base profile reference cycle: a -> b -> c -> a")
+	}
+	_, err = resolveBaseProfile(self, getBase)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "base profile reference cycle: self -> self")
+	}
+}
+
+func TestInheritsFrom(t *testing.T) {
+	baseProfiles := map[string]string{
+		"baseline": "",
+		"storage":  "baseline",
+		"nvme":     "storage",
+		"a":        "b",
+		"b":        "a",
+	}
+	assert.True(t, inheritsFrom("storage", "baseline", baseProfiles))
+	assert.True(t, inheritsFrom("nvme", "baseline", baseProfiles))
+	assert.False(t, inheritsFrom("baseline", "nvme", baseProfiles))
+	assert.False(t, inheritsFrom("nvme", "nvme", baseProfiles))
+	assert.True(t, inheritsFrom("a", "b", baseProfiles))
+	assert.False(t, inheritsFrom("a", "baseline", baseProfiles))
+}
+
+func TestBaseProfileLabels(t *testing.T) {
+	baseline := inheritingProfile("baseline", "", hwcc.HardwareCharacteristics{
+		Cpu: &hwcc.Cpu{MinimumCount: 32},
+	})
+	baseline.Spec.HostSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"baseline": "true"},
+	}
+	large := inheritingProfile("large", "baseline", hwcc.HardwareCharacteristics{
+		Cpu: &hwcc.Cpu{MaximumCount: 64},
+	})
+	cycle := inheritingProfile("cycle", "cycle", hwcc.HardwareCharacteristics{})
+
+	testCases := []struct {
+		Scenario string
+		CPUCount int
+		Expected map[string]string
+	}{
+		{
+			Scenario: "inherited-minimum",
+			CPUCount: 16,
+		},
+		{
+			Scenario: "matched",
+			CPUCount: 48,
+			Expected: map[string]string{"hardwareclassification.metal3.io/large": "matches"},
+		},
+		{
+			Scenario: "overridden-maximum",
+			CPUCount: 96,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := testHost("metal3", "host-0", nil, tc.CPUCount)
+			c := newTestClient(baseline, large, cycle, host)
+			r := &BareMetalHostReconciler{
+				Client: c,
+				Log:    ctrl.Log.WithName("test"),
+			}
+			key := types.NamespacedName{Namespace: "metal3", Name: "host-0"}
+			_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+
+			updated := &bmh.BareMetalHost{}
+			assert.NoError(t, c.Get(context.TODO(), key, updated))
+			if tc.Expected == nil {
+				assert.Empty(t, updated.Labels)
+			} else {
+				assert.Equal(t, tc.Expected, updated.Labels)
+			}
+		})
+	}
+}
+
+func TestBaseProfileStatus(t *testing.T) {
+	cycle := inheritingProfile("cycle", "cycle", hwcc.HardwareCharacteristics{})
+	cycle.Finalizers = []string{hwcc.Finalizer}
+	c := newTestClient(cycle)
+	r := &HardwareClassificationReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("test"),
+	}
+	key := types.NamespacedName{Namespace: "metal3", Name: "cycle"}
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+
+	updated := &hwcc.HardwareClassification{}
+	assert.NoError(t, c.Get(context.TODO(), key, updated))
+	assert.Equal(t, hwcc.ProfileMisConfigured, updated.Status.ErrorType)
+	assert.Contains(t, updated.Status.ErrorMessage, "base profile reference cycle")
+}
@@ -0,0 +1,392 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+	"github.com/metal3-io/hardware-classification-controller/classifier"
+	"github.com/metal3-io/hardware-classification-controller/utils"
+	"github.com/pkg/errors"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ClusterHardwareClassificationReconciler reconciles a
+// ClusterHardwareClassification object
+type ClusterHardwareClassificationReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// ConfigMaps is the source of the events of the ConfigMaps holding
+	// serial numbers
+	ConfigMaps source.Source
+	// ConfigMapLister reads the ConfigMaps holding serial numbers from
+	// the informer behind ConfigMaps
+	ConfigMapLister corelisters.ConfigMapLister
+}
+
+// Reconcile reconcile function
+func (r *ClusterHardwareClassificationReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+
+	hwcLog := r.Log.WithValues("clusterhardwareclassification", req.Name)
+
+	clusterProfile := &hwcc.ClusterHardwareClassification{}
+	if err := r.Client.Get(ctx, req.NamespacedName, clusterProfile); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Add a finalizer to newly created objects.
+	if clusterProfile.DeletionTimestamp.IsZero() && !utils.StringInList(clusterProfile.Finalizers, hwcc.Finalizer) {
+		hwcLog.Info(
+			"adding finalizer",
+			"existingFinalizers", clusterProfile.Finalizers,
+			"newValue", hwcc.Finalizer,
+		)
+		clusterProfile.Finalizers = append(clusterProfile.Finalizers, hwcc.Finalizer)
+		err := r.Update(context.TODO(), clusterProfile)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to add finalizer")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	bmhHostList := bmh.BareMetalHostList{}
+	err := r.List(context.TODO(), &bmhHostList)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "could not fetch host list")
+	}
+
+	namespaceList := corev1.NamespaceList{}
+	err = r.List(context.TODO(), &namespaceList)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "could not fetch namespace list")
+	}
+	selectedNamespaces := map[string]bool{}
+	for i := range namespaceList.Items {
+		if namespaceSelected(clusterProfile, &namespaceList.Items[i]) {
+			selectedNamespaces[namespaceList.Items[i].Name] = true
+		}
+	}
+
+	hostSelector, err := classifier.HostSelector(clusterProfile.Profile(""))
+	if err != nil {
+		hostSelector = labels.Nothing()
+	}
+
+	// Count hosts with our label, in total to decide whether it is OK
+	// to delete this profile, and per selected namespace to report
+	// whether we have matched.
+	labelKey, _ := getClusterLabelDetails(clusterProfile)
+	scoreKey := scoreAnnotationPrefix + labelKey
+	matchCount := 0
+	namespaceMatchCounts := map[string]int{}
+	namespaceTierMatchCounts := map[string]map[string]int{}
+	for _, host := range bmhHostList.Items {
+		if selectedNamespaces[host.Namespace] {
+			if _, ok := namespaceMatchCounts[host.Namespace]; !ok {
+				namespaceMatchCounts[host.Namespace] = 0
+			}
+		}
+		if _, ok := host.GetAnnotations()[scoreKey]; ok {
+			// A host with a score below the threshold has no label,
+			// but its score must also be removed before deleting.
+			if _, ok := host.GetLabels()[labelKey]; !ok {
+				matchCount++
+				continue
+			}
+		}
+		hostLabels := host.GetLabels()
+		if hostLabels == nil {
+			continue
+		}
+		if _, ok := hostLabels[labelKey]; !ok {
+			continue
+		}
+		hwcLog.Info("found host with label",
+			"host", fmt.Sprintf("%s/%s", host.Namespace, host.Name),
+			"label", labelKey,
+		)
+		matchCount++
+		if selectedNamespaces[host.Namespace] && hostSelector.Matches(labels.Set(hostLabels)) {
+			namespaceMatchCounts[host.Namespace]++
+			if namespaceTierMatchCounts[host.Namespace] == nil {
+				namespaceTierMatchCounts[host.Namespace] = map[string]int{}
+			}
+			namespaceTierMatchCounts[host.Namespace][hostLabels[labelKey]]++
+		}
+	}
+
+	// Wait to delete the profile until no hosts are labeled as
+	// matching its rules.
+	if !clusterProfile.DeletionTimestamp.IsZero() {
+
+		if matchCount > 0 {
+			hwcLog.Info("waiting to delete")
+			return ctrl.Result{}, nil
+		}
+
+		clusterProfile.Finalizers = utils.FilterStringFromList(
+			clusterProfile.Finalizers, hwcc.Finalizer)
+		err = r.Update(context.TODO(), clusterProfile)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to remove finalizer")
+		}
+
+		hwcLog.Info("deleting")
+		return ctrl.Result{}, nil
+	}
+
+	// Update our status to report whether we have matched hosts in
+	// each of the selected namespaces with hosts, and whether the
+	// profile is misconfigured.
+	status := hwcc.ClusterHardwareClassificationStatus{
+		ErrorType:          hwcc.Empty,
+		ProfileMatchStatus: hwcc.ProfileMatchStatusUnMatched,
+		ErrorMessage:       hwcc.NOError,
+	}
+	if err := classifier.ValidateClusterProfile(clusterProfile); err != nil {
+		hwcLog.Info("invalid profile", "error", err.Error())
+		status.ErrorType, status.ErrorMessage = hwcc.ProfileMisConfigured, err.Error()
+	} else if effective, err := resolveBaseProfile(clusterProfile.Profile(""),
+		clusterBaseProfiles(ctx, r.Client, "")); err != nil {
+		hwcLog.Info("could not resolve base profile", "error", err.Error())
+		status.ErrorType, status.ErrorMessage = hwcc.ProfileMisConfigured, err.Error()
+	} else if err := classifier.ValidateProfile(effective); err != nil {
+		hwcLog.Info("invalid profile", "error", err.Error())
+		status.ErrorType, status.ErrorMessage = hwcc.ProfileMisConfigured, err.Error()
+	}
+	status.MissingRequiredProfiles, err = missingClusterRequiredProfiles(ctx, r.Client, clusterProfile)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for namespace, count := range namespaceMatchCounts {
+		namespaceStatus := hwcc.NamespaceMatchStatus{
+			Namespace:          namespace,
+			ProfileMatchStatus: hwcc.ProfileMatchStatusUnMatched,
+			MatchedHosts:       count,
+			Tiers:              tierMatchStatus(clusterProfile.Spec.Tiers, namespaceTierMatchCounts[namespace]),
+		}
+		if count > 0 {
+			namespaceStatus.ProfileMatchStatus = hwcc.ProfileMatchStatusMatched
+			status.ProfileMatchStatus = hwcc.ProfileMatchStatusMatched
+		}
+		// Serial numbers are read from the namespace of each host, so
+		// a missing ConfigMap only affects the hosts of one namespace.
+		if status.ErrorType == hwcc.Empty {
+			if err := r.checkSerialNumbers(ctx, clusterProfile, namespace); err != nil {
+				hwcLog.Info("could not resolve serial numbers",
+					"namespace", namespace,
+					"error", err.Error(),
+				)
+				namespaceStatus.ErrorMessage = err.Error()
+			}
+		}
+		status.Namespaces = append(status.Namespaces, namespaceStatus)
+	}
+	sort.Slice(status.Namespaces, func(i, j int) bool {
+		return status.Namespaces[i].Namespace < status.Namespaces[j].Namespace
+	})
+
+	if !apiequality.Semantic.DeepEqual(clusterProfile.Status, status) {
+		hwcLog.Info("updating status",
+			"matchStatus", status.ProfileMatchStatus,
+			"errorType", status.ErrorType,
+		)
+		clusterProfile.Status = status
+		err = r.Status().Update(context.TODO(), clusterProfile)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to update status")
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// checkSerialNumbers checks that the serial numbers of the cluster
+// profile, merged with its base profiles, can be read from the
+// ConfigMaps of the namespace
+func (r *ClusterHardwareClassificationReconciler) checkSerialNumbers(ctx context.Context, clusterProfile *hwcc.ClusterHardwareClassification, namespace string) error {
+	effective, err := resolveBaseProfile(clusterProfile.Profile(namespace),
+		clusterBaseProfiles(ctx, r.Client, namespace))
+	if err != nil {
+		return err
+	}
+	_, err = resolveSerialNumbers(r.ConfigMapLister, effective)
+	return err
+}
+
+// namespaceSelected checks if the namespace is selected by the cluster
+// profile, a profile with an invalid selector selects no namespace
+func namespaceSelected(profile *hwcc.ClusterHardwareClassification, namespace *corev1.Namespace) bool {
+	selector, err := classifier.NamespaceSelector(profile)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(namespace.Labels))
+}
+
+// SetupWithManager will add watches for this controller
+func (r *ClusterHardwareClassificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+
+	mapper := clusterClassificationMapper{
+		client: mgr.GetClient(),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&hwcc.ClusterHardwareClassification{}).
+		Named("cluster-hardware-classification").
+		Watches(&source.Kind{Type: &bmh.BareMetalHost{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: &mapper}).
+		Watches(&source.Kind{Type: &corev1.Namespace{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: &mapper}).
+		Watches(r.ConfigMaps,
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: &clusterConfigMapClassificationMapper{client: mgr.GetClient()}}).
+		Watches(&source.Kind{Type: &hwcc.ClusterHardwareClassification{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: &clusterDependentClassificationMapper{client: mgr.GetClient()}}).
+		Complete(r)
+}
+
+// clusterClassificationMapper requests all of the cluster profiles
+type clusterClassificationMapper struct {
+	client client.Client
+}
+
+func (m *clusterClassificationMapper) Map(obj handler.MapObject) []ctrl.Request {
+	log := ctrl.Log.WithName("controllers").WithName("ClusterHardwareClassification").WithName("mapper").
+		WithValues("object",
+			fmt.Sprintf("%s/%s", obj.Meta.GetNamespace(), obj.Meta.GetName()))
+
+	clusterProfileList := hwcc.ClusterHardwareClassificationList{}
+	err := m.client.List(context.TODO(), &clusterProfileList)
+	if err != nil {
+		log.Error(err, "could not fetch cluster hardware classification list")
+		return nil
+	}
+
+	requests := []ctrl.Request{}
+	for _, profile := range clusterProfileList.Items {
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name: profile.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// clusterConfigMapClassificationMapper requests the cluster profiles
+// reading serial numbers from a ConfigMap, in any namespace
+type clusterConfigMapClassificationMapper struct {
+	client client.Client
+}
+
+func (m *clusterConfigMapClassificationMapper) Map(obj handler.MapObject) []ctrl.Request {
+	log := ctrl.Log.WithName("controllers").WithName("ClusterHardwareClassification").WithName("mapper").
+		WithValues("ConfigMap",
+			fmt.Sprintf("%s/%s", obj.Meta.GetNamespace(), obj.Meta.GetName()))
+
+	clusterProfileList := hwcc.ClusterHardwareClassificationList{}
+	err := m.client.List(context.TODO(), &clusterProfileList)
+	if err != nil {
+		log.Error(err, "could not fetch cluster hardware classification list")
+		return nil
+	}
+
+	// Profiles inheriting from a profile referencing the ConfigMap read
+	// the serial numbers too.
+	baseProfiles := map[string]string{}
+	referencing := []string{}
+	for i := range clusterProfileList.Items {
+		profile := &clusterProfileList.Items[i]
+		baseProfiles[profile.Name] = profile.Spec.BaseProfile
+		if referencesConfigMap(profile.Profile(""), obj.Meta.GetName()) {
+			referencing = append(referencing, profile.Name)
+		}
+	}
+
+	requests := []ctrl.Request{}
+	for i := range clusterProfileList.Items {
+		profile := &clusterProfileList.Items[i]
+		if !referencesConfigMapOrInherits(profile.Name, referencing, baseProfiles) {
+			continue
+		}
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name: profile.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// clusterDependentClassificationMapper requests the cluster profiles
+// inheriting from or requiring a cluster profile
+type clusterDependentClassificationMapper struct {
+	client client.Client
+}
+
+func (m *clusterDependentClassificationMapper) Map(obj handler.MapObject) []ctrl.Request {
+	log := ctrl.Log.WithName("controllers").WithName("ClusterHardwareClassification").WithName("mapper").
+		WithValues("ClusterHardwareClassification", obj.Meta.GetName())
+
+	clusterProfileList := hwcc.ClusterHardwareClassificationList{}
+	err := m.client.List(context.TODO(), &clusterProfileList)
+	if err != nil {
+		log.Error(err, "could not fetch cluster hardware classification list")
+		return nil
+	}
+
+	baseProfiles := map[string]string{}
+	for _, profile := range clusterProfileList.Items {
+		baseProfiles[profile.Name] = profile.Spec.BaseProfile
+	}
+
+	requests := []ctrl.Request{}
+	for i := range clusterProfileList.Items {
+		profile := &clusterProfileList.Items[i]
+		if !inheritsFrom(profile.Name, obj.Meta.GetName(), baseProfiles) &&
+			!requiresProfile(profile.Profile(""), obj.Meta.GetName()) {
+			continue
+		}
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name: profile.Name,
+			},
+		})
+	}
+	return requests
+}
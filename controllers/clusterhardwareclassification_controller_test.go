@@ -0,0 +1,241 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func newTestClient(objs ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = hwcc.AddToScheme(scheme)
+	_ = bmh.AddToScheme(scheme)
+	return fake.NewFakeClientWithScheme(scheme, objs...)
+}
+
+func testNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
+
+func testHost(namespace, name string, labels map[string]string, cpuCount int) *bmh.BareMetalHost {
+	return &bmh.BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Status: bmh.BareMetalHostStatus{
+			HardwareDetails: &bmh.HardwareDetails{
+				CPU: bmh.CPU{
+					Count: cpuCount,
+				},
+			},
+		},
+	}
+}
+
+func testClusterProfile() *hwcc.ClusterHardwareClassification {
+	return &hwcc.ClusterHardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "cluster-profile",
+			Finalizers: []string{hwcc.Finalizer},
+		},
+		Spec: hwcc.ClusterHardwareClassificationSpec{
+			HardwareClassificationSpec: hwcc.HardwareClassificationSpec{
+				HardwareCharacteristics: hwcc.HardwareCharacteristics{
+					Cpu: &hwcc.Cpu{
+						MinimumCount: 32,
+					},
+				},
+			},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tenant": "true"},
+			},
+		},
+	}
+}
+
+func TestGetClusterLabelDetails(t *testing.T) {
+	profile := testClusterProfile()
+	label, value := getClusterLabelDetails(profile)
+	assert.Equal(t, "clusterhardwareclassification.metal3.io/cluster-profile", label)
+	assert.Equal(t, "matches", value)
+
+	profile.Labels = map[string]string{"cluster-profile": "alternate-value"}
+	_, value = getClusterLabelDetails(profile)
+	assert.Equal(t, "alternate-value", value)
+}
+
+func TestNamespaceSelected(t *testing.T) {
+	profile := testClusterProfile()
+	assert.True(t, namespaceSelected(profile, testNamespace("tenant-a", map[string]string{"tenant": "true"})))
+	assert.False(t, namespaceSelected(profile, testNamespace("kube-system", nil)))
+
+	profile.Spec.NamespaceSelector = nil
+	assert.True(t, namespaceSelected(profile, testNamespace("kube-system", nil)))
+}
+
+func TestClusterHardwareClassificationStatus(t *testing.T) {
+	label := map[string]string{"clusterhardwareclassification.metal3.io/cluster-profile": "matches"}
+	c := newTestClient(
+		testClusterProfile(),
+		testNamespace("tenant-a", map[string]string{"tenant": "true"}),
+		testNamespace("tenant-b", map[string]string{"tenant": "true"}),
+		testNamespace("other", nil),
+		testHost("tenant-a", "host-0", label, 40),
+		testHost("tenant-a", "host-1", nil, 8),
+		testHost("tenant-b", "host-0", nil, 8),
+		testHost("other", "host-0", label, 40),
+	)
+	r := &ClusterHardwareClassificationReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("test"),
+	}
+
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Name: "cluster-profile"}})
+	assert.NoError(t, err)
+
+	profile := &hwcc.ClusterHardwareClassification{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "cluster-profile"}, profile))
+	assert.Equal(t, hwcc.ProfileMatchStatusMatched, profile.Status.ProfileMatchStatus)
+	assert.Equal(t, []hwcc.NamespaceMatchStatus{
+		{Namespace: "tenant-a", ProfileMatchStatus: hwcc.ProfileMatchStatusMatched, MatchedHosts: 1},
+		{Namespace: "tenant-b", ProfileMatchStatus: hwcc.ProfileMatchStatusUnMatched, MatchedHosts: 0},
+	}, profile.Status.Namespaces)
+}
+
+func testSerialsClusterProfile() *hwcc.ClusterHardwareClassification {
+	profile := testClusterProfile()
+	profile.Spec.HardwareCharacteristics.SystemVendor = &hwcc.SystemVendor{
+		DeniedSerialNumbers: &hwcc.SerialNumberList{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "serials"},
+				Key:                  "rma",
+			},
+		},
+	}
+	return profile
+}
+
+func TestClusterHardwareClassificationSerialNumbers(t *testing.T) {
+	c := newTestClient(
+		testSerialsClusterProfile(),
+		testNamespace("tenant-a", map[string]string{"tenant": "true"}),
+		testNamespace("tenant-b", map[string]string{"tenant": "true"}),
+		testHost("tenant-a", "host-0", nil, 40),
+		testHost("tenant-b", "host-0", nil, 40),
+	)
+	r := &ClusterHardwareClassificationReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("test"),
+		ConfigMapLister: testConfigMapLister(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "serials",
+				Namespace: "tenant-a",
+				Labels:    map[string]string{hwcc.SerialNumbersLabel: ""},
+			},
+			Data: map[string]string{"rma": "CN7016300Q0012"},
+		}),
+	}
+
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Name: "cluster-profile"}})
+	assert.NoError(t, err)
+
+	profile := &hwcc.ClusterHardwareClassification{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "cluster-profile"}, profile))
+	assert.Equal(t, hwcc.Empty, profile.Status.ErrorType)
+	if assert.Len(t, profile.Status.Namespaces, 2) {
+		assert.Equal(t, "", profile.Status.Namespaces[0].ErrorMessage)
+		assert.Contains(t, profile.Status.Namespaces[1].ErrorMessage, "ConfigMap serials")
+	}
+}
+
+func TestConfigMapHostMapper(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "serials",
+			Namespace: "tenant-a",
+			Labels:    map[string]string{hwcc.SerialNumbersLabel: ""},
+		},
+	}
+	c := newTestClient(
+		testSerialsClusterProfile(),
+		testHost("tenant-a", "host-0", nil, 40),
+		testHost("tenant-b", "host-0", nil, 40),
+	)
+
+	mapper := &configMapHostMapper{hostMapper: hostMapper{client: c}}
+	obj := handler.MapObject{Meta: configMap, Object: configMap}
+	assert.Empty(t, mapper.Map(obj))
+
+	mapper.clusterProfiles = true
+	assert.Equal(t, []ctrl.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "tenant-a", Name: "host-0"}},
+	}, mapper.Map(obj))
+}
+
+func TestBareMetalHostClusterProfiles(t *testing.T) {
+	testCases := []struct {
+		Scenario  string
+		Namespace *corev1.Namespace
+		Host      *bmh.BareMetalHost
+		Labeled   bool
+	}{
+		{
+			Scenario:  "matched",
+			Namespace: testNamespace("tenant-a", map[string]string{"tenant": "true"}),
+			Host:      testHost("tenant-a", "host-0", nil, 40),
+			Labeled:   true,
+		},
+		{
+			Scenario:  "unmatched",
+			Namespace: testNamespace("tenant-a", map[string]string{"tenant": "true"}),
+			Host:      testHost("tenant-a", "host-0", nil, 8),
+			Labeled:   false,
+		},
+		{
+			Scenario:  "namespace-not-selected",
+			Namespace: testNamespace("other", nil),
+			Host: testHost("other", "host-0",
+				map[string]string{"clusterhardwareclassification.metal3.io/cluster-profile": "matches"}, 40),
+			Labeled: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			c := newTestClient(testClusterProfile(), tc.Namespace, tc.Host)
+			r := &BareMetalHostReconciler{
+				Client:          c,
+				Log:             ctrl.Log.WithName("test"),
+				ClusterProfiles: true,
+			}
+			key := types.NamespacedName{Namespace: tc.Host.Namespace, Name: tc.Host.Name}
+			_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+
+			host := &bmh.BareMetalHost{}
+			assert.NoError(t, c.Get(context.TODO(), key, host))
+			_, labeled := host.Labels["clusterhardwareclassification.metal3.io/cluster-profile"]
+			assert.Equal(t, tc.Labeled, labeled)
+		})
+	}
+}
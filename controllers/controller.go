@@ -5,7 +5,21 @@ package controllers
 // +kubebuilder:rbac:groups=metal3.io,resources=hardwareclassifications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=metal3.io,resources=hardwareclassifications/status,verbs=get;update;patch
 
+// RBAC Rules for ClusterHardwareClassification resources
+//
+// +kubebuilder:rbac:groups=metal3.io,resources=clusterhardwareclassifications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metal3.io,resources=clusterhardwareclassifications/status,verbs=get;update;patch
+
 // RBAC rules for BareMetalHost resources
 //
 // +kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts/status,verbs=get
+
+// RBAC rules for the ConfigMaps holding serial numbers, which are only
+// listed and watched with the SerialNumbersLabel selector
+//
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch
+
+// RBAC rules for the namespaces selected by cluster profiles
+//
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
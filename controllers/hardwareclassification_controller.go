@@ -21,13 +21,17 @@ import (
 
 	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+	"github.com/metal3-io/hardware-classification-controller/classifier"
 	"github.com/metal3-io/hardware-classification-controller/utils"
 	"github.com/pkg/errors"
 
 	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -44,6 +48,13 @@ type HardwareClassificationReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+
+	// ConfigMaps is the source of the events of the ConfigMaps holding
+	// serial numbers
+	ConfigMaps source.Source
+	// ConfigMapLister reads the ConfigMaps holding serial numbers from
+	// the informer behind ConfigMaps
+	ConfigMapLister corelisters.ConfigMapLister
 }
 
 // Reconcile reconcile function
@@ -90,15 +101,30 @@ func (hcReconciler *HardwareClassificationReconciler) Reconcile(req ctrl.Request
 	}
 
 	// Count hosts with our label. We use this value to decide whether
-	// we have matched and whether it is OK to delete this profile.
+	// it is OK to delete this profile, and the count of the hosts
+	// selected by the profile to decide whether we have matched.
+	selector, err := classifier.HostSelector(hardwareClassification)
+	if err != nil {
+		selector = labels.Nothing()
+	}
 	labelKey, _ := getLabelDetails(hardwareClassification)
-	matchCount := 0
+	scoreKey := scoreAnnotationPrefix + labelKey
+	matchCount, selectedMatchCount := 0, 0
+	tierMatchCounts := map[string]int{}
 	for _, host := range bmhHostList.Items {
-		labels := host.GetLabels()
-		if labels == nil {
+		if _, ok := host.GetAnnotations()[scoreKey]; ok {
+			// A host with a score below the threshold has no label,
+			// but its score must also be removed before deleting.
+			if _, ok := host.GetLabels()[labelKey]; !ok {
+				matchCount++
+				continue
+			}
+		}
+		hostLabels := host.GetLabels()
+		if hostLabels == nil {
 			continue
 		}
-		if _, ok := labels[labelKey]; !ok {
+		if _, ok := hostLabels[labelKey]; !ok {
 			continue
 		}
 		hwcLog.Info("found host with label",
@@ -106,6 +132,10 @@ func (hcReconciler *HardwareClassificationReconciler) Reconcile(req ctrl.Request
 			"label", labelKey,
 		)
 		matchCount++
+		if selector.Matches(labels.Set(hostLabels)) {
+			selectedMatchCount++
+			tierMatchCounts[hostLabels[labelKey]]++
+		}
 	}
 
 	// Wait to delete the hardwareClassification resource until no
@@ -133,14 +163,45 @@ func (hcReconciler *HardwareClassificationReconciler) Reconcile(req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
-	// Update our status to report whether we have matched a host or not.
+	// Update our status to report whether we have matched a host or not,
+	// and whether the profile is misconfigured.
 	status := hwcc.ProfileMatchStatusMatched
-	if matchCount == 0 {
+	if selectedMatchCount == 0 {
 		status = hwcc.ProfileMatchStatusUnMatched
 	}
-	if hardwareClassification.Status.ProfileMatchStatus != status {
-		hwcLog.Info("updating match status", "newValue", status)
+	errorType, errorMessage := hwcc.Empty, hwcc.NOError
+	effective, err := resolveBaseProfile(hardwareClassification,
+		namespacedBaseProfiles(ctx, hcReconciler.Client, hardwareClassification.Namespace))
+	if err != nil {
+		hwcLog.Info("could not resolve base profile", "error", err.Error())
+		errorType, errorMessage = hwcc.ProfileMisConfigured, err.Error()
+	} else if err := classifier.ValidateProfile(effective); err != nil {
+		hwcLog.Info("invalid profile", "error", err.Error())
+		errorType, errorMessage = hwcc.ProfileMisConfigured, err.Error()
+	} else if _, err := resolveSerialNumbers(hcReconciler.ConfigMapLister, effective); err != nil {
+		hwcLog.Info("could not resolve profile", "error", err.Error())
+		errorType, errorMessage = hwcc.ProfileMisConfigured, err.Error()
+	}
+	missing, err := missingRequiredProfiles(ctx, hcReconciler.Client, hardwareClassification)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	tiers := tierMatchStatus(hardwareClassification.Spec.Tiers, tierMatchCounts)
+	if hardwareClassification.Status.ProfileMatchStatus != status ||
+		hardwareClassification.Status.ErrorType != errorType ||
+		hardwareClassification.Status.ErrorMessage != errorMessage ||
+		!apiequality.Semantic.DeepEqual(hardwareClassification.Status.Tiers, tiers) ||
+		!apiequality.Semantic.DeepEqual(hardwareClassification.Status.MissingRequiredProfiles, missing) {
+		hwcLog.Info("updating status",
+			"matchStatus", status,
+			"errorType", errorType,
+			"missingRequiredProfiles", missing,
+		)
 		hardwareClassification.Status.ProfileMatchStatus = status
+		hardwareClassification.Status.ErrorType = errorType
+		hardwareClassification.Status.ErrorMessage = errorMessage
+		hardwareClassification.Status.Tiers = tiers
+		hardwareClassification.Status.MissingRequiredProfiles = missing
 		err = hcReconciler.Status().Update(context.TODO(), hardwareClassification)
 		if err != nil {
 			return ctrl.Result{}, errors.Wrap(err, "failed to update status")
@@ -151,6 +212,22 @@ func (hcReconciler *HardwareClassificationReconciler) Reconcile(req ctrl.Request
 	return ctrl.Result{}, nil
 }
 
+// tierMatchStatus returns the count of hosts labeled with each of the
+// tiers, given the counts by label value
+func tierMatchStatus(tiers []hwcc.Tier, counts map[string]int) []hwcc.TierMatchStatus {
+	if len(tiers) == 0 {
+		return nil
+	}
+	status := make([]hwcc.TierMatchStatus, 0, len(tiers))
+	for _, tier := range tiers {
+		status = append(status, hwcc.TierMatchStatus{
+			Name:         tier.Name,
+			MatchedHosts: counts[tier.Name],
+		})
+	}
+	return status
+}
+
 func hasFinalizer(profile *hwcc.HardwareClassification) bool {
 	return utils.StringInList(profile.Finalizers, hwcc.Finalizer)
 }
@@ -167,6 +244,10 @@ func (hcReconciler *HardwareClassificationReconciler) SetupWithManager(mgr ctrl.
 		Named("hardware-classification").
 		Watches(&source.Kind{Type: &bmh.BareMetalHost{}},
 			&handler.EnqueueRequestsFromMapFunc{ToRequests: &mapper}).
+		Watches(hcReconciler.ConfigMaps,
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: &configMapClassificationMapper{client: mgr.GetClient()}}).
+		Watches(&source.Kind{Type: &hwcc.HardwareClassification{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: &dependentClassificationMapper{client: mgr.GetClient()}}).
 		Complete(hcReconciler)
 }
 
@@ -203,3 +284,104 @@ func (m *classificationMapper) Map(obj handler.MapObject) []ctrl.Request {
 	}
 	return requests
 }
+
+// configMapClassificationMapper requests the profiles reading serial
+// numbers from a ConfigMap
+type configMapClassificationMapper struct {
+	client client.Client
+}
+
+func (m *configMapClassificationMapper) Map(obj handler.MapObject) []ctrl.Request {
+	log := ctrl.Log.WithName("controllers").WithName("HardwareClassification").WithName("mapper").
+		WithValues("ConfigMap",
+			fmt.Sprintf("%s/%s", obj.Meta.GetNamespace(), obj.Meta.GetName()))
+
+	hwcList := hwcc.HardwareClassificationList{}
+	opts := &client.ListOptions{
+		Namespace: obj.Meta.GetNamespace(),
+	}
+	err := m.client.List(context.TODO(), &hwcList, opts)
+	if err != nil {
+		log.Error(err, "could not fetch hardware classification list")
+		return nil
+	}
+
+	// Profiles inheriting from a profile referencing the ConfigMap read
+	// the serial numbers too.
+	baseProfiles := map[string]string{}
+	referencing := []string{}
+	for i := range hwcList.Items {
+		profile := &hwcList.Items[i]
+		baseProfiles[profile.Name] = profile.Spec.BaseProfile
+		if referencesConfigMap(profile, obj.Meta.GetName()) {
+			referencing = append(referencing, profile.Name)
+		}
+	}
+
+	requests := []ctrl.Request{}
+	for i := range hwcList.Items {
+		profile := &hwcList.Items[i]
+		if !referencesConfigMapOrInherits(profile.Name, referencing, baseProfiles) {
+			continue
+		}
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      profile.Name,
+				Namespace: profile.Namespace,
+			},
+		})
+	}
+	return requests
+}
+
+func referencesConfigMapOrInherits(name string, referencing []string, baseProfiles map[string]string) bool {
+	for _, base := range referencing {
+		if name == base || inheritsFrom(name, base, baseProfiles) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependentClassificationMapper requests the profiles inheriting from
+// or requiring a profile
+type dependentClassificationMapper struct {
+	client client.Client
+}
+
+func (m *dependentClassificationMapper) Map(obj handler.MapObject) []ctrl.Request {
+	log := ctrl.Log.WithName("controllers").WithName("HardwareClassification").WithName("mapper").
+		WithValues("HardwareClassification",
+			fmt.Sprintf("%s/%s", obj.Meta.GetNamespace(), obj.Meta.GetName()))
+
+	hwcList := hwcc.HardwareClassificationList{}
+	opts := &client.ListOptions{
+		Namespace: obj.Meta.GetNamespace(),
+	}
+	err := m.client.List(context.TODO(), &hwcList, opts)
+	if err != nil {
+		log.Error(err, "could not fetch hardware classification list")
+		return nil
+	}
+
+	baseProfiles := map[string]string{}
+	for _, profile := range hwcList.Items {
+		baseProfiles[profile.Name] = profile.Spec.BaseProfile
+	}
+
+	requests := []ctrl.Request{}
+	for i := range hwcList.Items {
+		profile := &hwcList.Items[i]
+		if !inheritsFrom(profile.Name, obj.Meta.GetName(), baseProfiles) &&
+			!requiresProfile(profile, obj.Meta.GetName()) {
+			continue
+		}
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      profile.Name,
+				Namespace: profile.Namespace,
+			},
+		})
+	}
+	return requests
+}
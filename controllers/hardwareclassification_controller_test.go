@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestTiers(t *testing.T) {
+	profile := &hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "compute",
+			Namespace:  "metal3",
+			Finalizers: []string{hwcc.Finalizer},
+		},
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				Cpu: &hwcc.Cpu{MinimumCount: 8},
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{
+						Name: "64-cpus",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Cpu: &hwcc.Cpu{MinimumCount: 64},
+						},
+					},
+					{
+						Name: "32-cpus",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Cpu: &hwcc.Cpu{MinimumCount: 32},
+						},
+					},
+					{
+						Name: "16-cpus",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Cpu: &hwcc.Cpu{MinimumCount: 16},
+						},
+					},
+				},
+			},
+			Tiers: []hwcc.Tier{
+				{Name: "gold", Block: "64-cpus"},
+				{Name: "silver", Block: "32-cpus"},
+				{Name: "bronze", Block: "16-cpus"},
+			},
+		},
+	}
+	hosts := []struct {
+		Name     string
+		CPUCount int
+		Expected map[string]string
+	}{
+		{
+			Name:     "host-0",
+			CPUCount: 96,
+			Expected: map[string]string{"hardwareclassification.metal3.io/compute": "gold"},
+		},
+		{
+			Name:     "host-1",
+			CPUCount: 64,
+			Expected: map[string]string{"hardwareclassification.metal3.io/compute": "gold"},
+		},
+		{
+			Name:     "host-2",
+			CPUCount: 40,
+			Expected: map[string]string{"hardwareclassification.metal3.io/compute": "silver"},
+		},
+		{
+			Name:     "host-3",
+			CPUCount: 8,
+		},
+		{
+			Name:     "host-4",
+			CPUCount: 4,
+		},
+	}
+
+	c := newTestClient(profile)
+	for _, host := range hosts {
+		assert.NoError(t, c.Create(context.TODO(), testHost("metal3", host.Name, nil, host.CPUCount)))
+	}
+
+	r := &BareMetalHostReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("test"),
+	}
+	for _, host := range hosts {
+		key := types.NamespacedName{Namespace: "metal3", Name: host.Name}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		assert.NoError(t, err)
+
+		updated := &bmh.BareMetalHost{}
+		assert.NoError(t, c.Get(context.TODO(), key, updated))
+		if host.Expected == nil {
+			assert.Empty(t, updated.Labels, host.Name)
+		} else {
+			assert.Equal(t, host.Expected, updated.Labels, host.Name)
+		}
+	}
+
+	hcReconciler := &HardwareClassificationReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("test"),
+	}
+	key := types.NamespacedName{Namespace: "metal3", Name: "compute"}
+	_, err := hcReconciler.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+
+	updated := &hwcc.HardwareClassification{}
+	assert.NoError(t, c.Get(context.TODO(), key, updated))
+	assert.Equal(t, hwcc.ProfileMatchStatusMatched, updated.Status.ProfileMatchStatus)
+	assert.Equal(t, []hwcc.TierMatchStatus{
+		{Name: "gold", MatchedHosts: 2},
+		{Name: "silver", MatchedHosts: 1},
+		{Name: "bronze", MatchedHosts: 0},
+	}, updated.Status.Tiers)
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// missingRequiredProfiles returns the required profiles of the profile
+// which do not exist in its namespace or are being deleted
+func missingRequiredProfiles(ctx context.Context, c client.Client, profile *hwcc.HardwareClassification) ([]string, error) {
+	return missingProfiles(profile.Spec.RequiredProfiles, func(name string) (metav1.Object, error) {
+		required := &hwcc.HardwareClassification{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: profile.Namespace, Name: name}, required)
+		return required, err
+	})
+}
+
+// missingClusterRequiredProfiles returns the required cluster profiles
+// of the cluster profile which do not exist or are being deleted
+func missingClusterRequiredProfiles(ctx context.Context, c client.Client, profile *hwcc.ClusterHardwareClassification) ([]string, error) {
+	return missingProfiles(profile.Spec.RequiredProfiles, func(name string) (metav1.Object, error) {
+		required := &hwcc.ClusterHardwareClassification{}
+		err := c.Get(ctx, types.NamespacedName{Name: name}, required)
+		return required, err
+	})
+}
+
+func missingProfiles(names []string, get func(name string) (metav1.Object, error)) ([]string, error) {
+	var missing []string
+	for _, name := range names {
+		required, err := get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				missing = append(missing, name)
+				continue
+			}
+			return nil, errors.Wrapf(err, "could not load required profile %s", name)
+		}
+		if !required.GetDeletionTimestamp().IsZero() {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// requiresProfile reports whether the profile requires the named
+// profile
+func requiresProfile(profile *hwcc.HardwareClassification, name string) bool {
+	for _, required := range profile.Spec.RequiredProfiles {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
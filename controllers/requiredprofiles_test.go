@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	bmh "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+func TestApplyRequiredProfiles(t *testing.T) {
+	newResult := func(name string, cluster, matched bool, required ...string) *classification {
+		return &classification{
+			profile: &hwcc.HardwareClassification{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: hwcc.HardwareClassificationSpec{
+					RequiredProfiles: required,
+				},
+			},
+			cluster: cluster,
+			matched: matched,
+		}
+	}
+
+	testCases := []struct {
+		Scenario string
+		Results  []*classification
+		Expected []bool
+	}{
+		{
+			Scenario: "required-matched",
+			Results: []*classification{
+				newResult("storage-fast", false, true, "baseline-approved"),
+				newResult("baseline-approved", false, true),
+			},
+			Expected: []bool{true, true},
+		},
+		{
+			Scenario: "required-unmatched",
+			Results: []*classification{
+				newResult("storage-fast", false, true, "baseline-approved"),
+				newResult("baseline-approved", false, false),
+			},
+			Expected: []bool{false, false},
+		},
+		{
+			Scenario: "required-missing",
+			Results: []*classification{
+				newResult("storage-fast", false, true, "baseline-approved"),
+			},
+			Expected: []bool{false},
+		},
+		{
+			Scenario: "transitive",
+			Results: []*classification{
+				newResult("a", false, true, "b"),
+				newResult("b", false, true, "c"),
+				newResult("c", false, false),
+			},
+			Expected: []bool{false, false, false},
+		},
+		{
+			Scenario: "scope",
+			Results: []*classification{
+				newResult("storage-fast", false, true, "baseline-approved"),
+				newResult("baseline-approved", true, true),
+			},
+			Expected: []bool{false, true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			applyRequiredProfiles(ctrl.Log.WithName("test"), tc.Results)
+			for i, result := range tc.Results {
+				assert.Equal(t, tc.Expected[i], result.matched, result.profile.Name)
+			}
+		})
+	}
+}
+
+func TestRequiredProfileLabels(t *testing.T) {
+	baseline := &hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baseline-approved",
+			Namespace: "metal3",
+		},
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				Cpu: &hwcc.Cpu{MinimumCount: 16},
+			},
+		},
+	}
+	storageFast := &hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "storage-fast",
+			Namespace: "metal3",
+		},
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				Cpu: &hwcc.Cpu{MaximumCount: 64},
+			},
+			RequiredProfiles: []string{"baseline-approved"},
+		},
+	}
+
+	testCases := []struct {
+		Scenario string
+		CPUCount int
+		Labels   map[string]string
+		Expected map[string]string
+	}{
+		{
+			Scenario: "both",
+			CPUCount: 32,
+			Expected: map[string]string{
+				"hardwareclassification.metal3.io/baseline-approved": "matches",
+				"hardwareclassification.metal3.io/storage-fast":      "matches",
+			},
+		},
+		{
+			Scenario: "stale-label",
+			CPUCount: 8,
+			Labels: map[string]string{
+				"hardwareclassification.metal3.io/baseline-approved": "matches",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := testHost("metal3", "host-0", tc.Labels, tc.CPUCount)
+			c := newTestClient(baseline, storageFast, host)
+			r := &BareMetalHostReconciler{
+				Client: c,
+				Log:    ctrl.Log.WithName("test"),
+			}
+			key := types.NamespacedName{Namespace: "metal3", Name: "host-0"}
+			_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+
+			updated := &bmh.BareMetalHost{}
+			assert.NoError(t, c.Get(context.TODO(), key, updated))
+			if tc.Expected == nil {
+				assert.Empty(t, updated.Labels)
+			} else {
+				assert.Equal(t, tc.Expected, updated.Labels)
+			}
+		})
+	}
+}
+
+func TestMissingRequiredProfiles(t *testing.T) {
+	now := metav1.Now()
+	profile := &hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "storage-fast",
+			Namespace:  "metal3",
+			Finalizers: []string{hwcc.Finalizer},
+		},
+		Spec: hwcc.HardwareClassificationSpec{
+			RequiredProfiles: []string{"baseline-approved", "deleted", "missing"},
+		},
+	}
+	c := newTestClient(
+		profile,
+		&hwcc.HardwareClassification{
+			ObjectMeta: metav1.ObjectMeta{Name: "baseline-approved", Namespace: "metal3"},
+		},
+		&hwcc.HardwareClassification{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "deleted",
+				Namespace:         "metal3",
+				DeletionTimestamp: &now,
+				Finalizers:        []string{hwcc.Finalizer},
+			},
+		},
+		&hwcc.HardwareClassification{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "other"},
+		},
+	)
+	r := &HardwareClassificationReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("test"),
+	}
+	key := types.NamespacedName{Namespace: "metal3", Name: "storage-fast"}
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+
+	updated := &hwcc.HardwareClassification{}
+	assert.NoError(t, c.Get(context.TODO(), key, updated))
+	assert.Equal(t, []string{"deleted", "missing"}, updated.Status.MissingRequiredProfiles)
+}
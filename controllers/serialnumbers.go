@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+	"github.com/metal3-io/hardware-classification-controller/classifier"
+)
+
+// serialNumberLists returns the serial number lists of the profile,
+// including the ones of its named blocks
+func serialNumberLists(profile *hwcc.HardwareClassification) []*hwcc.SerialNumberList {
+	characteristics := &profile.Spec.HardwareCharacteristics
+	lists := blockSerialNumberLists(characteristics.SystemVendor, characteristics.Disk)
+	for i := range characteristics.Blocks {
+		block := &characteristics.Blocks[i]
+		lists = append(lists, blockSerialNumberLists(block.SystemVendor, block.Disk)...)
+	}
+	return lists
+}
+
+func blockSerialNumberLists(systemVendor *hwcc.SystemVendor, disk *hwcc.Disk) []*hwcc.SerialNumberList {
+	lists := []*hwcc.SerialNumberList{}
+	if systemVendor != nil {
+		lists = append(lists, systemVendor.AllowedSerialNumbers, systemVendor.DeniedSerialNumbers)
+	}
+	if disk != nil {
+		lists = append(lists, disk.AllowedSerialNumbers, disk.DeniedSerialNumbers)
+	}
+	return lists
+}
+
+// referencesConfigMap reports whether the profile reads serial numbers
+// from the named ConfigMap
+func referencesConfigMap(profile *hwcc.HardwareClassification, name string) bool {
+	for _, list := range serialNumberLists(profile) {
+		if list != nil && list.ConfigMapKeyRef != nil && list.ConfigMapKeyRef.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSerialNumbers returns a copy of the profile with the serial
+// numbers read from ConfigMaps added to the values of the lists, so
+// the classifier does not need to access the API. The ConfigMaps are
+// read from the lister of NewSerialNumberConfigMapSource, which only
+// holds the ConfigMaps with the SerialNumbersLabel label.
+func resolveSerialNumbers(configMaps corelisters.ConfigMapLister, profile *hwcc.HardwareClassification) (*hwcc.HardwareClassification, error) {
+	resolved := profile.DeepCopy()
+	for _, list := range serialNumberLists(resolved) {
+		if list == nil || list.ConfigMapKeyRef == nil {
+			continue
+		}
+		ref := list.ConfigMapKeyRef
+
+		configMap, err := configMaps.ConfigMaps(profile.Namespace).Get(ref.Name)
+		if err != nil {
+			if ref.Optional != nil && *ref.Optional {
+				list.ConfigMapKeyRef = nil
+				continue
+			}
+			if apierrors.IsNotFound(err) {
+				return nil, errors.Errorf("ConfigMap %s with the %s label not found",
+					ref.Name, hwcc.SerialNumbersLabel)
+			}
+			return nil, errors.Wrapf(err, "could not load serial numbers from ConfigMap %s", ref.Name)
+		}
+		data, ok := configMap.Data[ref.Key]
+		if !ok {
+			if ref.Optional != nil && *ref.Optional {
+				list.ConfigMapKeyRef = nil
+				continue
+			}
+			return nil, errors.Errorf("ConfigMap %s has no key %s", ref.Name, ref.Key)
+		}
+		list.Values = append(list.Values, classifier.ParseSerialNumbers(data)...)
+	}
+	return resolved, nil
+}
+
+// NewSerialNumberConfigMapSource returns a source of the events of the
+// ConfigMaps with the SerialNumbersLabel label in the namespace, or in
+// all of the namespaces if it is empty, and a lister reading them from
+// the same informer. The informer only lists the labeled ConfigMaps,
+// unlike the cache of the manager, and is started with the manager.
+// The source and the lister can be shared by controllers.
+func NewSerialNumberConfigMapSource(mgr ctrl.Manager, namespace string) (source.Source, corelisters.ConfigMapLister, error) {
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = hwcc.SerialNumbersLabel
+		}))
+	configMaps := factory.Core().V1().ConfigMaps()
+	informer := configMaps.Informer()
+
+	err = mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		factory.Start(stop)
+		<-stop
+		return nil
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &syncingInformer{Informer: source.Informer{Informer: informer}}, configMaps.Lister(), nil
+}
+
+// syncingInformer makes the controllers wait for the informer before
+// reconciling, so missing serial numbers are not reported while the
+// ConfigMaps are still being listed.
+type syncingInformer struct {
+	source.Informer
+}
+
+func (s *syncingInformer) WaitForSync(stop <-chan struct{}) error {
+	if !toolscache.WaitForCacheSync(stop, s.Informer.Informer.HasSynced) {
+		return errors.New("could not sync the serial number ConfigMaps")
+	}
+	return nil
+}
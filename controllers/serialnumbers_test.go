@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	hwcc "github.com/metal3-io/hardware-classification-controller/api/v1alpha1"
+)
+
+// testConfigMapLister returns a lister of the labeled ConfigMaps, as
+// the informer of NewSerialNumberConfigMapSource would
+func testConfigMapLister(configMaps ...*corev1.ConfigMap) corelisters.ConfigMapLister {
+	indexer := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc,
+		toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc})
+	for _, configMap := range configMaps {
+		if _, ok := configMap.Labels[hwcc.SerialNumbersLabel]; ok {
+			_ = indexer.Add(configMap)
+		}
+	}
+	return corelisters.NewConfigMapLister(indexer)
+}
+
+func serialsProfile(ref *corev1.ConfigMapKeySelector) *hwcc.HardwareClassification {
+	return &hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "profile-name",
+			Namespace: "profile-namespace",
+		},
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				SystemVendor: &hwcc.SystemVendor{
+					AllowedSerialNumbers: &hwcc.SerialNumberList{
+						Values:          []string{"CN7016300Q0011"},
+						ConfigMapKeyRef: ref,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveSerialNumbers(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "serials",
+			Namespace: "profile-namespace",
+			Labels:    map[string]string{hwcc.SerialNumbersLabel: ""},
+		},
+		Data: map[string]string{
+			"batch": "CN7016300Q0012\nCN7016300Q0013\n",
+		},
+	}
+	unlabeled := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "unlabeled",
+			Namespace: "profile-namespace",
+		},
+		Data: configMap.Data,
+	}
+	optional := true
+
+	testCases := []struct {
+		Scenario string
+		Ref      *corev1.ConfigMapKeySelector
+		Expected []string
+		Error    bool
+	}{
+		{
+			Scenario: "no-ref",
+			Expected: []string{"CN7016300Q0011"},
+		},
+		{
+			Scenario: "found",
+			Ref: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "serials"},
+				Key:                  "batch",
+			},
+			Expected: []string{"CN7016300Q0011", "CN7016300Q0012", "CN7016300Q0013"},
+		},
+		{
+			Scenario: "missing-configmap",
+			Ref: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "other"},
+				Key:                  "batch",
+			},
+			Error: true,
+		},
+		{
+			Scenario: "missing-key",
+			Ref: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "serials"},
+				Key:                  "other",
+			},
+			Error: true,
+		},
+		{
+			Scenario: "unlabeled",
+			Ref: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "unlabeled"},
+				Key:                  "batch",
+			},
+			Error: true,
+		},
+		{
+			Scenario: "optional-missing-key",
+			Ref: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "serials"},
+				Key:                  "other",
+				Optional:             &optional,
+			},
+			Expected: []string{"CN7016300Q0011"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			lister := testConfigMapLister(configMap.DeepCopy(), unlabeled.DeepCopy())
+			profile := serialsProfile(tc.Ref)
+			resolved, err := resolveSerialNumbers(lister, profile)
+			if tc.Error {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, resolved.Spec.HardwareCharacteristics.SystemVendor.AllowedSerialNumbers.Values)
+			assert.Equal(t, []string{"CN7016300Q0011"}, profile.Spec.HardwareCharacteristics.SystemVendor.AllowedSerialNumbers.Values)
+		})
+	}
+}
+
+func TestReferencesConfigMap(t *testing.T) {
+	ref := &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "serials"},
+		Key:                  "batch",
+	}
+	assert.True(t, referencesConfigMap(serialsProfile(ref), "serials"))
+	assert.False(t, referencesConfigMap(serialsProfile(ref), "other"))
+	assert.False(t, referencesConfigMap(serialsProfile(nil), "serials"))
+
+	blocks := &hwcc.HardwareClassification{
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{
+						Name: "dell",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							SystemVendor: &hwcc.SystemVendor{Manufacturer: "Dell Inc."},
+						},
+					},
+					{
+						Name: "denied-disks",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Disk: &hwcc.Disk{DeniedSerialNumbers: &hwcc.SerialNumberList{ConfigMapKeyRef: ref}},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, referencesConfigMap(blocks, "serials"))
+	assert.False(t, referencesConfigMap(blocks, "other"))
+}
+
+func TestResolveBlockSerialNumbers(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "serials",
+			Namespace: "profile-namespace",
+			Labels:    map[string]string{hwcc.SerialNumbersLabel: ""},
+		},
+		Data: map[string]string{
+			"batch": "S3EVNX0K123456",
+		},
+	}
+	profile := &hwcc.HardwareClassification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "profile-name",
+			Namespace: "profile-namespace",
+		},
+		Spec: hwcc.HardwareClassificationSpec{
+			HardwareCharacteristics: hwcc.HardwareCharacteristics{
+				Blocks: []hwcc.NamedCharacteristicsBlock{
+					{
+						Name: "approved-disks",
+						CharacteristicsBlock: hwcc.CharacteristicsBlock{
+							Disk: &hwcc.Disk{
+								AllowedSerialNumbers: &hwcc.SerialNumberList{
+									ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "serials"},
+										Key:                  "batch",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Tiers: []hwcc.Tier{
+				{Name: "gold", Block: "approved-disks"},
+			},
+		},
+	}
+
+	resolved, err := resolveSerialNumbers(testConfigMapLister(configMap), profile)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"S3EVNX0K123456"},
+		resolved.Spec.HardwareCharacteristics.Blocks[0].Disk.AllowedSerialNumbers.Values)
+}
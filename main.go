@@ -23,6 +23,7 @@ import (
 
 	bmoapis "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 
+	"github.com/metal3-io/hardware-classification-controller/classifier"
 	"github.com/metal3-io/hardware-classification-controller/controllers"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -50,17 +51,30 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var watchNamespace string
+	var vendorAliasesPath string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&watchNamespace, "namespace", "",
 		"Namespace that the controller watches to reconcile HWCC objects. If unspecified, the controller watches for HWCC objects across all namespaces.")
+	flag.StringVar(&vendorAliasesPath, "vendor-aliases", "",
+		"Path to a YAML file mapping raw manufacturer and BIOS vendor names to canonical names, e.g. mounted from a ConfigMap.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(func(o *zap.Options) {
 		o.Development = true
 	}))
 
+	if vendorAliasesPath != "" {
+		aliases, err := classifier.LoadVendorAliases(vendorAliasesPath)
+		if err != nil {
+			setupLog.Error(err, "unable to load vendor aliases")
+			os.Exit(1)
+		}
+		classifier.SetVendorAliases(aliases)
+		setupLog.Info("loaded vendor aliases", "count", len(aliases))
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
@@ -75,18 +89,46 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Only the labeled ConfigMaps holding serial numbers are watched and
+	// cached, rather than every ConfigMap.
+	configMaps, configMapLister, err := controllers.NewSerialNumberConfigMapSource(mgr, watchNamespace)
+	if err != nil {
+		setupLog.Error(err, "unable to watch serial number ConfigMaps")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.HardwareClassificationReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("HardwareClassification"),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Log:             ctrl.Log.WithName("controllers").WithName("HardwareClassification"),
+		Scheme:          mgr.GetScheme(),
+		ConfigMaps:      configMaps,
+		ConfigMapLister: configMapLister,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HardwareClassification")
 		os.Exit(1)
 	}
+	// Cluster profiles classify the hosts of every namespace, so they
+	// are only available when all of the namespaces are watched.
+	clusterProfiles := watchNamespace == ""
+	if clusterProfiles {
+		if err = (&controllers.ClusterHardwareClassificationReconciler{
+			Client:          mgr.GetClient(),
+			Log:             ctrl.Log.WithName("controllers").WithName("ClusterHardwareClassification"),
+			Scheme:          mgr.GetScheme(),
+			ConfigMaps:      configMaps,
+			ConfigMapLister: configMapLister,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterHardwareClassification")
+			os.Exit(1)
+		}
+	}
 	if err = (&controllers.BareMetalHostReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("BareMetalHost"),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Log:             ctrl.Log.WithName("controllers").WithName("BareMetalHost"),
+		Scheme:          mgr.GetScheme(),
+		ClusterProfiles: clusterProfiles,
+		ConfigMaps:      configMaps,
+		ConfigMapLister: configMapLister,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BareMetalHost")
 		os.Exit(1)